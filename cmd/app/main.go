@@ -1,11 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	auditlogrepository "goproject/internal/app/repository/auditlog"
+	blogrepository "goproject/internal/app/repository/blog"
+	commentrepository "goproject/internal/app/repository/comment"
+	listrepository "goproject/internal/app/repository/list"
+	loginattemptrepository "goproject/internal/app/repository/loginattempt"
+	mediarepository "goproject/internal/app/repository/media"
+	oidcauthrequestrepository "goproject/internal/app/repository/oidcauthrequest"
+	postrepository "goproject/internal/app/repository/post"
+	postrevisionrepository "goproject/internal/app/repository/postrevision"
+	userrepository "goproject/internal/app/repository/user"
+	usertokenrepository "goproject/internal/app/repository/usertoken"
+	accountusecase "goproject/internal/app/usecase/account"
+	lockoutusecase "goproject/internal/app/usecase/lockout"
+	mediausecase "goproject/internal/app/usecase/media"
+	postusecase "goproject/internal/app/usecase/post"
+	"goproject/internal/helpers"
 	"goproject/internal/infrastructure/database"
 	httproute "goproject/internal/infrastructure/http"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/infrastructure/scheduler"
+	"goproject/internal/infrastructure/storage"
 	"goproject/internal/utils"
 	"os"
+	"time"
 
 	"goproject/docs"
 )
@@ -32,9 +53,97 @@ func main() {
 		panic(err)
 	}
 
+	migrator, err := database.NewMigrator(db.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = migrate(context.Background(), migrator, os.Args[2:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// the schema is only changed through the migrate command, so refuse to serve on an outdated one
+	pending, err := migrator.Pending(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	if len(pending) > 0 {
+		panic(fmt.Sprintf("database schema is %d migration(s) behind, run `main-app migrate up` first", len(pending)))
+	}
+
+	// nor on one a newer version of the app migrated, this one doesn't know what changed
+	unknown, err := migrator.Unknown(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	if len(unknown) > 0 {
+		panic(fmt.Sprintf("database schema is ahead of the app, migration(s) %v aren't known to this version, roll them back with the newer one or upgrade", unknown))
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "set-role" {
+		err = setRole(context.Background(), userrepository.NewUserRepository(db.DB), os.Args[2:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	err = utils.LoadJWTKeyring()
+	if err != nil {
+		panic(err)
+	}
+	err = helpers.LoadAppURL()
+	if err != nil {
+		panic(err)
+	}
+
 	logger := utils.NewLogger()
-	docs.SwaggerInfo.BasePath = "/api/v1"
+	docs.SwaggerInfo.BasePath = helpers.APIBasePath
+
+	postUsecase := postusecase.NewPostUsecase(postrepository.NewPostRepository(db.DB), postrevisionrepository.NewPostRevisionRepository(db.DB), blogrepository.NewBlogRepository(db.DB), logger)
+	go scheduler.Every(context.Background(), time.Minute, "publish scheduled posts", postUsecase.PublishScheduledPosts, logger)
+	go scheduler.Every(context.Background(), time.Minute, "render stale posts", postUsecase.RenderStalePosts, logger)
+
+	mailer, err := mail.NewMailer(logger)
+	if err != nil {
+		panic(err)
+	}
+	loginAttemptRepository, err := loginattemptrepository.NewLoginAttemptRepository(db.DB)
+	if err != nil {
+		panic(err)
+	}
+	lockoutUsecase := lockoutusecase.NewLockoutUsecase(loginAttemptRepository, auditlogrepository.NewAuditLogRepository(db.DB), userrepository.NewUserRepository(db.DB), usertokenrepository.NewUserTokenRepository(db.DB), mailer, logger)
+	go scheduler.Every(context.Background(), time.Hour, "prune login attempts", lockoutUsecase.PruneAttempts, logger)
+
+	oidcAuthRequestRepository := oidcauthrequestrepository.NewOIDCAuthRequestRepository(db.DB)
+	go scheduler.Every(context.Background(), time.Hour, "prune oidc auth requests", func(ctx context.Context) error {
+		return oidcAuthRequestRepository.DeleteExpired(ctx, time.Now())
+	}, logger)
+
+	store, err := storage.NewBlobStore()
+	if err != nil {
+		panic(err)
+	}
+	mediaLimits, err := mediausecase.LimitsFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	mediaUsecase := mediausecase.NewMediaUsecase(mediarepository.NewMediaRepository(db.DB), store, mediaLimits, logger)
+	go scheduler.Every(context.Background(), 5*time.Second, "process media", mediaUsecase.ProcessPendingMedia, logger)
+
+	gracePeriod, err := accountusecase.GracePeriodFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	accountUsecase := accountusecase.NewAccountUsecase(userrepository.NewUserRepository(db.DB), blogrepository.NewBlogRepository(db.DB), postrepository.NewPostRepository(db.DB), commentrepository.NewCommentRepository(db.DB), listrepository.NewListRepository(db.DB), mediarepository.NewMediaRepository(db.DB), store, mailer, gracePeriod, logger)
+	go scheduler.Every(context.Background(), 10*time.Minute, "delete scheduled accounts", accountUsecase.DeleteScheduledAccounts, logger)
 
-	r := httproute.NewRoute(db.DB, logger)
+	r := httproute.NewRoute(db.DB, store, mailer, lockoutUsecase, mediaUsecase, mediaLimits, accountUsecase, logger)
 	r.Run(fmt.Sprintf(":%s", port))
 }
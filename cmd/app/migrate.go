@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/infrastructure/database"
+	"strconv"
+	"time"
+)
+
+const migrateUsage = `usage: main-app migrate <command>
+
+commands:
+  up            apply every pending migration
+  down [n]      roll back the last n migrations, 1 by default
+  status        list migrations and whether they've been applied
+  to <version>  migrate up or down to the given version, 0 rolls back everything`
+
+func migrate(ctx context.Context, migrator *database.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return errors.New("number of migrations to roll back must be a positive integer")
+			}
+			steps = n
+		}
+		return migrator.Down(ctx, steps)
+	case "to":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return errors.New("version must be a non-negative integer")
+		}
+		return migrator.To(ctx, uint(version))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
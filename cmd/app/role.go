@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/domain/policy"
+	"goproject/internal/domain/repository"
+)
+
+const setRoleUsage = `usage: main-app set-role <username> <user|moderator|admin>`
+
+// setRole changes a user's role from the command line, it's how the very first admin gets promoted.
+func setRole(ctx context.Context, userRepo repository.UserRepository, args []string) error {
+	if len(args) != 2 {
+		return errors.New(setRoleUsage)
+	}
+
+	username, role := args[0], args[1]
+	if !policy.IsValidRole(role) {
+		return errors.New(setRoleUsage)
+	}
+
+	n, err := userRepo.UpdateRole(ctx, username, role)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("user %s not found", username)
+	}
+
+	fmt.Printf("%s is now %s\n", username, role)
+	return nil
+}
@@ -16,48 +16,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Log in as an existing user by providing a username and password\nUpon successful login, a JWT will be provided",
+        "/admin/blog/{username}/posts/{post_slug}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove a post from any blog, along with its comments. Only admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Login as an existing user",
+                "summary": "Remove any post",
                 "parameters": [
                     {
-                        "description": "data required to login to an existing account",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.LoginResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -65,57 +63,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Create a new account by providing required data. This will automatically create a blog named: \"\u003cuser's name\u003e's blog\".\nUser's username and email must be unique. Meaning that there can't be 2 users using the same email/username.",
+        "/admin/comments/{comment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove a comment by its ID, wherever it was posted.\nA comment that already has replies is replaced by a \"[deleted]\" placeholder instead of being removed with its replies.\nOnly admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Create a new account",
+                "summary": "Remove any comment",
                 "parameters": [
                     {
-                        "description": "data required to create a new account",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
-                        }
+                        "type": "integer",
+                        "description": "comment's ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithError"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/helpers.InputError"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -123,65 +103,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/blog/my": {
-            "get": {
+        "/admin/comments/{comment_id}/hidden": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get current user's blog information (name, description, number of posts).",
+                "description": "Hide a comment site-wide, it's shown as \"[hidden]\" and can't be replied to. Moderators and admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blog"
+                    "Admin"
+                ],
+                "summary": "Hide a comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "comment's ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get current user's blog information",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.BlogResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Change current user's blog name and description.",
+                "description": "Make a hidden comment visible again. Moderators and admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blog"
+                    "Admin"
                 ],
-                "summary": "Change current user's blog information",
+                "summary": "Unhide a comment",
                 "parameters": [
                     {
-                        "description": "data required to change user's blog information",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateBlogRequest"
-                        }
+                        "type": "integer",
+                        "description": "comment's ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -190,32 +171,64 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
                     }
                 }
             }
         },
-        "/blog/my/posts": {
+        "/admin/reports": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get all current user's blog posts. When there are no posts, it will return an empty array ([]).",
+                "description": "Get reports on posts and comments, newest first. Moderators and admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Post"
+                    "Admin"
+                ],
+                "summary": "Get reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open (default) or resolved",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all current user's blog posts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
                                 },
                                 {
                                     "type": "object",
@@ -223,7 +236,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.PostResponse"
+                                                "$ref": "#/definitions/dto.ReportResponse"
                                             }
                                         }
                                     }
@@ -232,83 +245,74 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/reports/{report_id}/resolve": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Create a new post on current user's blog.\nUpon successful creation, it will return the newly created post's slug",
+                "description": "Mark a report as resolved once it has been dealt with. Moderators and admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Post"
+                    "Admin"
                 ],
-                "summary": "Create a new blog post",
+                "summary": "Resolve a report",
                 "parameters": [
                     {
-                        "description": "data required to create a new post",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.PostRequest"
-                        }
+                        "type": "integer",
+                        "description": "report's ID",
+                        "name": "report_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.CreatePostResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     }
                 }
             }
         },
-        "/blog/my/posts/{post_slug}": {
-            "put": {
+        "/admin/users/{username}/lockout": {
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update/modify current user's blog post by providing the post's slug.",
+                "description": "Let a user who got locked out after too many failed logins try again right away. Only admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Post"
+                    "Admin"
                 ],
-                "summary": "Update/modify current user's post",
+                "summary": "Lift a user's login lockout",
                 "parameters": [
-                    {
-                        "description": "data required to update/modify a post",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.PostRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
+                        "description": "username of the locked user",
+                        "name": "username",
                         "in": "path",
                         "required": true
                     }
@@ -327,28 +331,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/{username}/role": {
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete current user's blog post by providing the post slug.\nWhen a post is deleted, all comments on the post will also be deleted.\nAlso, if the post is part of someone's lists, the post in that list will also be deleted.",
+                "description": "Change a user's role to user, moderator or admin. The new role applies once the user's token is refreshed.\nOnly admins can access this path, and they can't change their own role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Post"
+                    "Admin"
                 ],
-                "summary": "Delete current user's post",
+                "summary": "Change a user's role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
+                        "description": "username of the user",
+                        "name": "username",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "the new role",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangeRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -367,20 +382,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/blog/{username}": {
-            "get": {
-                "description": "Get user's blog information (name, description, number of posts) by providing their username.",
+        "/admin/users/{username}/suspension": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Suspend a user, they're logged out everywhere and can't log in until the suspension is lifted.\nAdmins can't be suspended. Only admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Blog"
+                    "Admin"
                 ],
-                "summary": "Get user's blog information",
+                "summary": "Suspend a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username of the user",
+                        "description": "username of the user to suspend",
                         "name": "username",
                         "in": "path",
                         "required": true
@@ -390,56 +410,41 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.BlogResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithError"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     }
                 }
-            }
-        },
-        "/blog/{username}/posts": {
-            "get": {
-                "description": "Get user's blog posts by providing their username.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Lift a user's suspension so they can log in again. Only admins can access this path.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Post"
+                    "Admin"
                 ],
-                "summary": "Get a user's blog posts",
+                "summary": "Lift a user's suspension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "user's username",
+                        "description": "username of the suspended user",
                         "name": "username",
                         "in": "path",
                         "required": true
@@ -449,22 +454,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.PostResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
                     },
                     "404": {
@@ -476,30 +466,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/blog/{username}/posts/{post_slug}": {
-            "get": {
-                "description": "Get a specific post by providing their username and the post's slug.",
+        "/auth/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Enable two-factor authentication with a code from the authenticator app the secret was added to.\nThe response contains one-time recovery codes, they're only shown once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Post"
+                    "Two-Factor"
                 ],
-                "summary": "Get a specific post",
+                "summary": "Enable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user's username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
+                        "description": "code from the authenticator app",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CodeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -514,15 +504,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.PostResponse"
+                                            "$ref": "#/definitions/dto.RecoveryCodesResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -530,32 +520,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/blog/{username}/posts/{post_slug}/comments": {
-            "get": {
-                "description": "Get all comments on a post by post's URL.",
+        "/auth/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Disable two-factor authentication, both the password and a code (from the app or a recovery code) are required.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "Two-Factor"
                 ],
-                "summary": "Get comments on a post",
+                "summary": "Disable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "blog owner's username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
+                        "description": "current password and a two-factor code",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/2fa/enroll": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
+                        "BearerToken": []
                     }
                 ],
+                "description": "Generate a new TOTP secret for current user, along with an otpauth:// URI to add it to an authenticator app.\nTwo-factor authentication is only enabled once a code from the app is sent to /auth/2fa/confirm.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor"
+                ],
+                "summary": "Start enrolling in two-factor authentication",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -568,60 +595,51 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.CommentResponse"
-                                            }
+                                            "$ref": "#/definitions/dto.EnrollResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/auth/2fa/recovery-codes": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Create a new comment on a blog post.\nUpon successful creation, it will returns the newly created comment's ID.",
+                "description": "Replace current user's recovery codes with new ones, the old ones stop working. Requires a code from the authenticator app.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "Two-Factor"
                 ],
-                "summary": "Create a comment on a blog post",
+                "summary": "Regenerate recovery codes",
                 "parameters": [
                     {
-                        "description": "data required to create a comment",
+                        "description": "code from the authenticator app",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentRequest"
+                            "$ref": "#/definitions/dto.CodeRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "blog owner's username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -631,15 +649,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.CreateCommentResponse"
+                                            "$ref": "#/definitions/dto.RecoveryCodesResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -647,51 +665,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/blog/{username}/posts/{post_slug}/comments/{comment_id}": {
-            "put": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Edit current user's comment on a post by providing comment ID.",
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Send a single-use password reset token to the given email, it expires in 30 minutes.\nThe response is the same whether an account uses the email or not.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "Auth"
                 ],
-                "summary": "Edit current user's comment on a post",
+                "summary": "Ask for a password reset token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "blog owner's username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "data required to modify comment",
+                        "description": "email of the account",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CommentRequest"
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "comment's ID",
-                        "name": "comment_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -701,60 +693,78 @@ const docTemplate = `{
                             "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponseWithError"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Delete a comment on a post by comment's ID.\nA non-blog-owner user can only delete their own comment.\nBlog's owner is allowed to delete ANY comment on their posts.",
-                "produces": [
-                    "application/json"
-                ],
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.InputError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Log in as an existing user by providing a username and password\nUpon successful login, a short-lived JWT and a refresh token will be provided\nIf the account has two-factor authentication enabled, only a challenge token is provided, to be sent with a code to /auth/login/2fa\nAfter a few failed attempts, the username and the IP address have to wait longer and longer between attempts, and are locked for a while after too many.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Comment"
+                    "Auth"
                 ],
-                "summary": "Delete a comment",
+                "summary": "Login as an existing user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "blog owner's username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "comment's ID",
-                        "name": "comment_id",
-                        "in": "path",
-                        "required": true
+                        "description": "data required to login to an existing account",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -762,59 +772,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/blog/{username}/posts/{post_slug}/save/{list_slug}": {
+        "/auth/login/2fa": {
             "post": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Add a post to the current user's list by providing the slug of the list to which the user wants to add the post.",
+                "description": "Second step of logging in to an account with two-factor authentication.\nThe code is either from the authenticator app or one of the recovery codes. The challenge token can only be used once, even with a wrong code.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Add post to current user's list",
+                "summary": "Finish logging in with a two-factor code",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "blog owner's username",
-                        "name": "username",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "post's slug",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "list slug you want to add this post to",
-                        "name": "list_slug",
-                        "in": "path",
-                        "required": true
+                        "description": "challenge token from login and a two-factor code",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -822,83 +827,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/lists/my": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Get all of current user's lists\nWill return an empty array ([]) if the user has no lists.",
+                "description": "Revoke the session the current JWT belongs to. The JWT and every refresh token of this session will stop working.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Get current user's lists",
+                "summary": "Logout from current session",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/helpers.MyListResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/magic-link": {
             "post": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Create a new list for current user by providing required data.\nUpon successful creation, it will return the newly created list's slug.",
+                "description": "Email a single-use sign-in link to the account with the given email, it expires in 15 minutes and links sent before stop working.\nThe response is the same whether an account uses the email or not. At most 5 links are sent to an account per hour, further requests are silently ignored.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Create a new list",
+                "summary": "Ask for a sign-in link",
                 "parameters": [
                     {
-                        "description": "data required to create a new list",
+                        "description": "email of the account",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ListRequest"
+                            "$ref": "#/definitions/dto.MagicLinkRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.CreateListResponse"
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.InputError"
+                                            }
                                         }
                                     }
                                 }
@@ -908,27 +904,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/lists/my/{list_slug}": {
+        "/auth/magic-link/login": {
             "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Get posts in my current user's by providing the list's slug.",
+                "description": "Where sign-in links point to. Gives the same tokens as logging in with a password, accounts with 2FA only get a challenge token.\nThe link can only be used once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Get posts in current user's list",
+                "summary": "Log in with a sign-in link",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "slug of the list you want to get the post from",
-                        "name": "list_slug",
-                        "in": "path",
+                        "description": "sign-in token",
+                        "name": "token",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -944,140 +935,237 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/helpers.PostsInMyListResponse"
+                                            "$ref": "#/definitions/dto.LoginResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Update/modify current user's list information (name and description) by providing the list's slug.",
+            }
+        },
+        "/auth/magic-link/register": {
+            "post": {
+                "description": "Create a new account, along with its blog, that logs in through sign-in links instead of a password. A sign-in link is sent right away,\nopening it also verifies the email. A password can be set later through forgot-password.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Update/modify current user's list information",
+                "summary": "Create a new account without a password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "list slug you want to edit",
-                        "name": "list_slug",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "body required to update/modify list information",
-                        "name": "body",
+                        "description": "data required to create a new account",
+                        "name": "Body",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ListRequest"
+                            "$ref": "#/definitions/dto.MagicLinkRegisterRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponseWithError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.InputError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Delete current user's list by providing the list slug",
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Where the identity provider sends the user back to. The identity is linked to the account with the same verified email,\nor a new account, along with its blog, is created for it. Like with a password, accounts with 2FA only get a challenge token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Delete current user's list",
+                "summary": "Finish logging in with single sign-on",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "slug of the list you want to remove",
-                        "name": "list_slug",
-                        "in": "path",
+                        "description": "state given to the identity provider",
+                        "name": "state",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "why the identity provider refused the login",
+                        "name": "error",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "details of the error",
+                        "name": "error_description",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Redirect to the OpenID Connect identity provider to log in there. It sends the user back to /auth/oidc/callback.",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Log in with single sign-on",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
                     }
                 }
             }
         },
-        "/lists/my/{list_slug}/{post_slug}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Remove a post from current user's list by providing the list slug and post slug you want to remove.",
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new JWT and a new refresh token.\nA refresh token can only be used once. Using an already used refresh token will revoke the whole session.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "List"
+                    "Auth"
                 ],
-                "summary": "Remove a post from current user's list",
+                "summary": "Get a new access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "list slug you want to remove post from",
-                        "name": "list_slug",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "post slug you want to remove",
-                        "name": "post_slug",
-                        "in": "path",
-                        "required": true
+                        "description": "refresh token received from login or a previous refresh",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/helpers.ResponseWithError"
                         }
@@ -1085,79 +1173,119 @@ const docTemplate = `{
                 }
             }
         },
-        "/my/comments": {
-            "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Get current user's comments on all posts.",
+        "/auth/register": {
+            "post": {
+                "description": "Create a new account by providing required data. This will automatically create a blog named: \"\u003cuser's name\u003e's blog\".\nUser's username and email must be unique. Meaning that there can't be 2 users using the same email/username.\nA verification link is sent to the email, the account can't write posts or comments until it's opened.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "Auth"
+                ],
+                "summary": "Create a new account",
+                "parameters": [
+                    {
+                        "description": "data required to create a new account",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
                 ],
-                "summary": "Get current user's comments",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.CommentResponse"
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.InputError"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
                     }
                 }
             }
         },
-        "/users/me": {
-            "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password using the token sent by forgot-password. Every session and personal access token of the account is revoked along with it.",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Get user information about current logged in user",
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Get current user information",
-                "responses": {}
-            },
-            "put": {
-                "security": [
+                "summary": "Reset password",
+                "parameters": [
                     {
-                        "BearerToken": []
+                        "description": "reset token and the new password",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
                     }
                 ],
-                "description": "Update current user's information by providing required data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/unlock": {
+            "post": {
+                "description": "Lift a login lockout early, using the token emailed to the account's owner when it was locked.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Update current user's information",
+                "summary": "Unlock a locked account",
                 "parameters": [
                     {
-                        "description": "the body to update user's information",
+                        "description": "unlock token",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserUpdateInfoRequest"
+                            "$ref": "#/definitions/dto.UnlockRequest"
                         }
                     }
                 ],
@@ -1165,81 +1293,4193 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
                         }
                     }
                 }
             }
         },
-        "/users/me/update-password": {
-            "put": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Change user password by providing required data",
+        "/auth/verify": {
+            "get": {
+                "description": "Confirm the email of an account with the token from the link sent on registration or email change.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Auth"
                 ],
-                "summary": "Change user password",
+                "summary": "Verify email",
                 "parameters": [
                     {
-                        "description": "the body to change user's password",
-                        "name": "Body",
+                        "type": "string",
+                        "description": "verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify/resend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Send a new verification link to current user's email, links sent before stop working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Resend verification email",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get current user's blog information (name, description, number of posts).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog"
+                ],
+                "summary": "Get current user's blog information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BlogResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change current user's blog name and description.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog"
+                ],
+                "summary": "Change current user's blog information",
+                "parameters": [
+                    {
+                        "description": "data required to change user's blog information",
+                        "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdatePasswordRequest"
+                            "$ref": "#/definitions/dto.UpdateBlogRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get all current user's blog posts, including drafts, scheduled and archived posts. When there are no posts, it will return an empty array ([]).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get all current user's blog posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "only return posts with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PostResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a new post on current user's blog.\nA post can be a draft, scheduled (publish_at is required) or published. When status is omitted, the post is published right away.\nContent is Markdown (CommonMark with GitHub's tables, task lists, strikethrough and autolinks, plus footnotes), posts are returned with it rendered to sanitized HTML.\nUpon successful creation, it will return the newly created post's slug\nOnly users with a verified email can create posts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Create a new blog post",
+                "parameters": [
+                    {
+                        "description": "data required to create a new post",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreatePostResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Render Markdown content the same way a post's content is rendered, without saving anything.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Preview a post's content",
+                "parameters": [
+                    {
+                        "description": "Markdown content to render",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.InputError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts/{post_slug}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get current user's blog post by providing the post's slug, regardless of its status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get current user's post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PostResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update/modify current user's blog post by providing the post's slug.\nEvery update is kept as a new revision of the post.\nWhen status is omitted, the post keeps its current status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Update/modify current user's post",
+                "parameters": [
+                    {
+                        "description": "data required to update/modify a post",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete current user's blog post by providing the post slug.\nWhen a post is deleted, all comments on the post will also be deleted.\nAlso, if the post is part of someone's lists, the post in that list will also be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Delete current user's post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts/{post_slug}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get every revision of current user's post, newest first. Content is omitted, use the single revision endpoint to get it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get revisions of current user's post",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RevisionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts/{post_slug}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a line-level diff of the title and content between two revisions of current user's post.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Compare two revisions of current user's post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision to compare to",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RevisionDiffResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts/{post_slug}/revisions/{revision}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a single revision of current user's post, including its content.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get a revision of current user's post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision number",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RevisionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/my/posts/{post_slug}/revisions/{revision}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Restore the title and content of an old revision. The restored state is saved as a new revision, older revisions are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Restore a revision of current user's post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision number to restore",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}": {
+            "get": {
+                "description": "Get user's blog information (name, description, number of posts) by providing their username.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog"
+                ],
+                "summary": "Get user's blog information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username of the user",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BlogResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/feed.atom": {
+            "get": {
+                "description": "Get the newest published posts of a user's blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a blog's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only include posts with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/feed.json": {
+            "get": {
+                "description": "Get the newest published posts of a user's blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a blog's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only include posts with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/feed.rss": {
+            "get": {
+                "description": "Get the newest published posts of a user's blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a blog's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only include posts with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts": {
+            "get": {
+                "description": "Get user's published blog posts by providing their username.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get a user's blog posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only return posts with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PostResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}": {
+            "get": {
+                "description": "Get a specific published post by providing their username and the post's slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get a specific post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PostResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}/comments": {
+            "get": {
+                "description": "Get all comments on a post by post's URL.\nTop-level comments are paginated, newest first. Each of them comes with its replies as a tree, oldest first, up to max_depth levels deep.\nDeleted comments that still have replies are shown as \"[deleted]\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Get comments on a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "how many levels of replies to include, 3 by default and 10 at most",
+                        "name": "max_depth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CommentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a new comment on a blog post.\nUpon successful creation, it will returns the newly created comment's ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Create a comment on a blog post",
+                "parameters": [
+                    {
+                        "description": "data required to create a comment",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateCommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}/comments/{comment_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Edit current user's comment on a post by providing comment ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Edit current user's comment on a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data required to modify comment",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment's ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a comment on a post by comment's ID.\nA non-blog-owner user can only delete their own comment.\nBlog's owner is allowed to delete ANY comment on their posts, and so are admins on every post.\nA comment that already has replies is replaced by a \"[deleted]\" placeholder instead of being removed with its replies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Delete a comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment's ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}/comments/{comment_id}/replies": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a new comment on a blog post as a reply to another comment.\nUpon successful creation, it will returns the newly created comment's ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Reply to a comment",
+                "parameters": [
+                    {
+                        "description": "data required to create a comment",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the comment you want to reply to",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateCommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}/comments/{comment_id}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Report a comment on a post to the moderators, e.g. for spam or abuse.\nUpon successful creation, it will returns the newly created report's ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Report a comment",
+                "parameters": [
+                    {
+                        "description": "why the comment is being reported",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "comment's ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Report a published post to the moderators, e.g. for spam or abuse.\nUpon successful creation, it will returns the newly created report's ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Report a post",
+                "parameters": [
+                    {
+                        "description": "why the post is being reported",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReportRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/posts/{post_slug}/save/{list_slug}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Add a post to the current user's list by providing the slug of the list to which the user wants to add the post.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Add post to current user's list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "blog owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post's slug",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "list slug you want to add this post to",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/{username}/tags": {
+            "get": {
+                "description": "Get the tags used on a user's blog along with how many published posts have each tag, most used first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Get a blog's tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TagResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get published posts from the blogs of the users current user follows, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Post"
+                ],
+                "summary": "Get current user's feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PostResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get all of current user's lists\nWill return an empty array ([]) if the user has no lists.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Get current user's lists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.MyListResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a new list for current user by providing required data.\nUpon successful creation, it will return the newly created list's slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Create a new list",
+                "parameters": [
+                    {
+                        "description": "data required to create a new list",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/my/{list_slug}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get posts in my current user's by providing the list's slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Get posts in current user's list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of the list you want to get the post from",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/helpers.PostsInMyListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update/modify current user's list information (name and description) by providing the list's slug.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Update/modify current user's list information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "list slug you want to edit",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body required to update/modify list information",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete current user's list by providing the list slug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Delete current user's list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of the list you want to remove",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/my/{list_slug}/{post_slug}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove a post from current user's list by providing the list slug and post slug you want to remove.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Remove a post from current user's list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "list slug you want to remove post from",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "post slug you want to remove",
+                        "name": "post_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{username}/{list_slug}/feed.atom": {
+            "get": {
+                "description": "Get the posts in a public list as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nPrivate lists don't have a feed.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a list's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "list owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "slug of the list",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{username}/{list_slug}/feed.json": {
+            "get": {
+                "description": "Get the posts in a public list as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nPrivate lists don't have a feed.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a list's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "list owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "slug of the list",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/lists/{username}/{list_slug}/feed.rss": {
+            "get": {
+                "description": "Get the posts in a public list as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nPrivate lists don't have a feed.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a list's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "list owner's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "slug of the list",
+                        "name": "list_slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the files current user uploaded, newest first, along with their processing status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Get current user's media",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MediaResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload an image to use in posts, PNG, JPEG, GIF and WebP are allowed. The type is detected from the file's content.\nFiles count towards a per-user quota. The image is resized and stripped of its metadata in the background,\nonce its status is ready, use its url, srcset or markdown in a post's content to show it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload a media file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "the file to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MediaResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/files/{key}": {
+            "get": {
+                "description": "Download a processed image, this is where media urls point to unless the storage serves files itself.",
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Download a media file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "media's key, e.g. johndoe/abc.png",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{media_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete one of current user's files, posts that still use it won't be able to show it anymore.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Delete a media file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "media's ID",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/my/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get current user's comments on all posts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comment"
+                ],
+                "summary": "Get current user's comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/search/posts": {
+            "get": {
+                "description": "Full-text search over the title and content of published posts, best matches first.\nThe query supports web search syntax: \"quoted phrases\", OR, and -excluded words.\nEach result comes with a snippet of the matching content, with matched words wrapped in \u003cb\u003e\u003c/b\u003e.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only search posts on this user's blog",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PostSearchResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/feed.atom": {
+            "get": {
+                "description": "Get the newest published posts with a tag from every blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a tag's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of the tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/feed.json": {
+            "get": {
+                "description": "Get the newest published posts with a tag from every blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a tag's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of the tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/feed.rss": {
+            "get": {
+                "description": "Get the newest published posts with a tag from every blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.\nSupports conditional requests with If-None-Match and If-Modified-Since.",
+                "produces": [
+                    "application/atom+xml",
+                    "application/rss+xml",
+                    "application/feed+json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Get a tag's feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of the tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/posts": {
+            "get": {
+                "description": "Get published posts from every blog that are tagged with the given tag.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Get posts with a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "slug of the tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PostResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get user information about current logged in user",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get current user information",
+                "responses": {}
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update current user's information by providing required data\nChanging the email sends a verification link to the new one, posts and comments are blocked until it's opened.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update current user's information",
+                "parameters": [
+                    {
+                        "description": "the body to update user's information",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserUpdateInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Schedule current user's account for deletion once the grace period (14 days by default) is over, an email is sent to confirm it.\nUntil then the account works as usual and the deletion can be cancelled. The blog, posts, lists and files are then deleted,\nthe posts disappear from other users' lists too, and comments stay without their author.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete current user's account",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.DeletionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/cancel-deletion": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Keep current user's account, as long as its deletion hasn't happened yet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Cancel current user's account deletion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Download a zip archive of everything current user has: profile, blog, posts with their revisions, comments, lists and uploaded files.\nEach of them is in a JSON file, and posts and revisions are also in Markdown files with a front matter.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Download current user's data",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/privacy": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Choose what the public profile shows: the location, the follower and following counts and lists, and the comment count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update current user's privacy settings",
+                "parameters": [
+                    {
+                        "description": "the body to update user's privacy settings",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePrivacyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/profile": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Replace current user's bio, website, location, social links and avatar.\nThe avatar is one of the user's uploads, upload the image through the media endpoint first. Leaving it out removes the avatar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update current user's profile",
+                "parameters": [
+                    {
+                        "description": "the body to update user's profile",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get current user's tokens that haven't been revoked, along with when each was last used.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access Token"
+                ],
+                "summary": "Get current user's personal access tokens",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TokenResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a token for scripts and API clients to act as current user, limited to the given scopes.\nThe token is only shown once, send it as \"Authorization: Bearer \u003ctoken\u003e\".\nAccount settings, sessions and tokens themselves can't be managed with a personal access token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access Token"
+                ],
+                "summary": "Create a personal access token",
+                "parameters": [
+                    {
+                        "description": "name, scopes and optionally how many days the token lasts",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/helpers.InputError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/tokens/{token_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Revoke one of current user's tokens, it stops working immediately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access Token"
+                ],
+                "summary": "Revoke a personal access token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token's ID",
+                        "name": "token_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/update-password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change user password by providing required data\nAccounts created without a password have to set one through forgot password first.\nEvery personal access token of the account is revoked, and every session but the current one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "description": "the body to change user's password",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/username": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Rename the current user, their blog, posts and lists move to the new name.\nLinks with the old name redirect to the new one, and nobody else can register it for 90 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change current user's username",
+                "parameters": [
+                    {
+                        "description": "the body to change user's username",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUsernameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}": {
+            "get": {
+                "description": "Get a user's name, bio, links, avatar and how many published posts they have.\nTheir location, follower and following counts and comment count are left out when they hid them, and their email never shows.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get a user's public profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithData"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Follow a user, their published posts will show up in current user's feed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follow"
+                ],
+                "summary": "Follow a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username of the user to follow",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Stop following a user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follow"
+                ],
+                "summary": "Unfollow a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username of the user to unfollow",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithoutDataAndError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/followers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the users following a user, most recent first.\nOnly the user can see them when they keep their follows private, the token is optional otherwise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follow"
+                ],
+                "summary": "Get a user's followers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.FollowResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/following": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the users followed by a user, most recent first.\nOnly the user can see them when they keep their follows private, the token is optional otherwise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follow"
+                ],
+                "summary": "Get the users a user follows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user's username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page, 20 by default and 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the next page, taken from pagination.next",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous page, taken from pagination.prev",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helpers.ResponseWithPagination"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.FollowResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/helpers.ResponseWithError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AvatarResponse": {
+            "type": "object",
+            "properties": {
+                "srcset": {
+                    "description": "every variant, for an \u003cimg\u003e srcset attribute",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "the largest variant",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BlogResponse": {
+            "type": "object",
+            "properties": {
+                "blog_description": {
+                    "type": "string"
+                },
+                "blog_name": {
+                    "type": "string"
+                },
+                "blog_owner": {
+                    "type": "string"
+                },
+                "num_of_posts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ChangeRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "moderator",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "dto.CodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CommentRequest": {
+            "type": "object",
+            "required": [
+                "comment"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "comment_id": {
+                    "type": "integer"
+                },
+                "commenter": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "num_of_replies": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "post_url": {
+                    "type": "string"
+                },
+                "replies": {
+                    "description": "nil once max_depth is reached, even when num_of_replies isn't 0",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CommentResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCommentResponse": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateListResponse": {
+            "type": "object",
+            "properties": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreatePostResponse": {
+            "type": "object",
+            "properties": {
+                "post_slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateReportResponse": {
+            "type": "object",
+            "properties": {
+                "report_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateTokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "never expires when empty",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DeletionResponse": {
+            "type": "object",
+            "properties": {
+                "deletion_scheduled_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DisableRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FollowResponse": {
+            "type": "object",
+            "properties": {
+                "followed_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JSONFeed": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JSONFeedAuthor"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "feed_url": {
+                    "type": "string"
+                },
+                "home_page_url": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JSONFeedItem"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JSONFeedAuthor": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JSONFeedItem": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JSONFeedAuthor"
+                    }
+                },
+                "content_html": {
+                    "type": "string"
+                },
+                "date_modified": {
+                    "type": "string"
+                },
+                "date_published": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ListRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "is_public": {
+                    "description": "IsPublic is left as it is on update when it's missing, a new list is private without it",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.MagicLinkRegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.MagicLinkRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "markdown": {
+                    "description": "ready to be pasted into a post",
+                    "type": "string"
+                },
+                "media_id": {
+                    "type": "integer"
+                },
+                "processing_error": {
+                    "description": "why processing failed",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "srcset": {
+                    "description": "every variant, for an \u003cimg\u003e srcset attribute",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, processing, ready or failed",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "the largest variant",
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MediaVariantResponse"
+                    }
+                },
+                "width": {
+                    "description": "of the largest variant",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MediaVariantResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PostRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "publish_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published",
+                        "archived"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PostResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "description": "markdown source",
+                    "type": "string"
+                },
+                "content_html": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "type": "string"
+                },
+                "post_slug": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "reading_time_minutes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PostSearchResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "description": "markdown source",
+                    "type": "string"
+                },
+                "content_html": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "type": "string"
+                },
+                "post_slug": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "reading_time_minutes": {
+                    "type": "integer"
+                },
+                "snippet": {
+                    "description": "Snippet is escaped HTML, only the matches are marked up, with \u003cb\u003e tags",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PreviewRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PreviewResponse": {
+            "type": "object",
+            "properties": {
+                "content_html": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "type": "string"
+                },
+                "reading_time_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "$ref": "#/definitions/dto.AvatarResponse"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "comments": {
+                    "type": "integer"
+                },
+                "followers": {
+                    "type": "integer"
+                },
+                "following": {
+                    "type": "integer"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "posts": {
+                    "description": "published ones",
+                    "type": "integer"
+                },
+                "social_links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SocialLinkResponse"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                },
+                "username": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.BlogResponse": {
+        },
+        "dto.RefreshRequest": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "blog_description": {
+                "refresh_token": {
                     "type": "string"
-                },
-                "blog_name": {
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "blog_owner": {
+                "name": {
                     "type": "string"
                 },
-                "num_of_posts": {
-                    "type": "integer"
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "minLength": 6
                 }
             }
         },
-        "dto.CommentRequest": {
+        "dto.ReportRequest": {
             "type": "object",
             "required": [
-                "comment"
+                "reason"
             ],
             "properties": {
-                "comment": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
                 }
             }
         },
-        "dto.CommentResponse": {
+        "dto.ReportResponse": {
             "type": "object",
             "properties": {
                 "comment": {
@@ -1248,142 +5488,183 @@ const docTemplate = `{
                 "comment_id": {
                     "type": "integer"
                 },
-                "commenter": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
                 "post_url": {
                     "type": "string"
                 },
-                "updated_at": {
+                "reason": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.CreateCommentResponse": {
-            "type": "object",
-            "properties": {
-                "comment_id": {
+                },
+                "report_id": {
                     "type": "integer"
+                },
+                "reporter": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CreateListResponse": {
+        "dto.ResetPasswordRequest": {
             "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
             "properties": {
-                "slug": {
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 8
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreatePostResponse": {
+        "dto.RevisionDiffResponse": {
             "type": "object",
             "properties": {
-                "post_slug": {
-                    "type": "string"
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.DiffLine"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.DiffLine"
+                    }
+                },
+                "to": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ListRequest": {
+        "dto.RevisionResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "content": {
                     "type": "string"
                 },
-                "name": {
+                "created_at": {
+                    "type": "string"
+                },
+                "restored_from": {
+                    "type": "integer"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.SocialLinkRequest": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "label",
+                "url"
             ],
             "properties": {
-                "password": {
-                    "type": "string"
+                "label": {
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "username": {
-                    "type": "string"
+                "url": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "dto.LoginResponse": {
+        "dto.SocialLinkResponse": {
             "type": "object",
             "properties": {
-                "token": {
+                "label": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "dto.PostRequest": {
+        "dto.TagResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "title"
-            ],
             "properties": {
-                "content": {
+                "name": {
                     "type": "string"
                 },
-                "title": {
+                "num_of_posts": {
+                    "type": "integer"
+                },
+                "tag_slug": {
                     "type": "string"
                 }
             }
         },
-        "dto.PostResponse": {
+        "dto.TokenResponse": {
             "type": "object",
             "properties": {
-                "author": {
+                "created_at": {
                     "type": "string"
                 },
-                "content": {
+                "expires_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "last_used_at": {
                     "type": "string"
                 },
-                "post_slug": {
+                "name": {
                     "type": "string"
                 },
-                "title": {
+                "prefix": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "dto.TwoFactorLoginRequest": {
             "type": "object",
             "required": [
-                "email",
-                "name",
-                "password",
-                "username"
+                "challenge_token",
+                "code"
             ],
             "properties": {
-                "email": {
+                "challenge_token": {
                     "type": "string"
                 },
-                "name": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UnlockRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
                     "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 8
-                },
-                "username": {
-                    "type": "string",
-                    "minLength": 6
                 }
             }
         },
@@ -1418,6 +5699,64 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdatePrivacyRequest": {
+            "type": "object",
+            "required": [
+                "show_activity",
+                "show_follows",
+                "show_location"
+            ],
+            "properties": {
+                "show_activity": {
+                    "type": "boolean"
+                },
+                "show_follows": {
+                    "type": "boolean"
+                },
+                "show_location": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "avatar_media_id": {
+                    "type": "integer"
+                },
+                "bio": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "social_links": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "$ref": "#/definitions/dto.SocialLinkRequest"
+                    }
+                },
+                "website": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.UpdateUsernameRequest": {
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
         "dto.UserUpdateInfoRequest": {
             "type": "object",
             "required": [
@@ -1450,6 +5789,9 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "is_public": {
+                    "type": "boolean"
+                },
                 "list_slug": {
                     "type": "string"
                 },
@@ -1461,12 +5803,26 @@ const docTemplate = `{
                 }
             }
         },
+        "helpers.Pagination": {
+            "type": "object",
+            "properties": {
+                "next": {
+                    "type": "string"
+                },
+                "prev": {
+                    "type": "string"
+                }
+            }
+        },
         "helpers.PostsInMyListResponse": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
+                "is_public": {
+                    "type": "boolean"
+                },
                 "list_slug": {
                     "type": "string"
                 },
@@ -1505,6 +5861,21 @@ const docTemplate = `{
                 }
             }
         },
+        "helpers.ResponseWithPagination": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/helpers.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
         "helpers.ResponseWithoutDataAndError": {
             "type": "object",
             "properties": {
@@ -1515,6 +5886,17 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "utils.DiffLine": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+type CreateTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=profile:read blog:write posts:read posts:write comments:read comments:write lists:read lists:write follows:write media:read media:write"`
+	ExpiresInDays *int     `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // never expires when empty
+}
+
+type TokenResponse struct {
+	ID         uint       `json:"token_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateTokenResponse struct {
+	TokenResponse
+	Token string `json:"token"`
+}
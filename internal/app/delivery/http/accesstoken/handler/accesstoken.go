@@ -0,0 +1,127 @@
+package accesstokenhandler
+
+import (
+	"goproject/internal/app/delivery/http/accesstoken/dto"
+	accesstokenusecase "goproject/internal/app/usecase/accesstoken"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccessTokenHandler interface {
+	CreateToken(c *gin.Context)
+	GetMyTokens(c *gin.Context)
+	RevokeToken(c *gin.Context)
+}
+
+type accessTokenHandlerImpl struct {
+	uc accesstokenusecase.AccessTokenUsecase
+}
+
+func NewAccessTokenHandler(uc accesstokenusecase.AccessTokenUsecase) AccessTokenHandler {
+	return &accessTokenHandlerImpl{
+		uc: uc,
+	}
+}
+
+// CreateToken godoc
+//
+//	@Summary		Create a personal access token
+//	@Description	Create a token for scripts and API clients to act as current user, limited to the given scopes.
+//	@Description	The token is only shown once, send it as "Authorization: Bearer <token>".
+//	@Description	Account settings, sessions and tokens themselves can't be managed with a personal access token.
+//	@Tags			Access Token
+//	@Param			Body	body	dto.CreateTokenRequest	true	"name, scopes and optionally how many days the token lasts"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		201	{object}	helpers.ResponseWithData{data=dto.CreateTokenResponse}
+//	@Failure		400	{object}	helpers.ResponseWithError{error=[]helpers.InputError}
+//	@Router			/users/me/tokens [post]
+func (handler *accessTokenHandlerImpl) CreateToken(c *gin.Context) {
+	var data dto.CreateTokenRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "create token", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "create token", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.CreateToken(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "create token", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusCreated, "create token", nil, resp)
+}
+
+// GetMyTokens godoc
+//
+//	@Summary		Get current user's personal access tokens
+//	@Description	Get current user's tokens that haven't been revoked, along with when each was last used.
+//	@Tags			Access Token
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.TokenResponse}
+//	@Router			/users/me/tokens [get]
+func (handler *accessTokenHandlerImpl) GetMyTokens(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "get tokens", "you're not allowed to access this path", nil)
+		return
+	}
+
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get tokens", err.Error(), nil)
+		return
+	}
+
+	tokens, pagination, ucErr := handler.uc.GetMyTokens(c, username, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get tokens", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get tokens", tokens, pagination)
+}
+
+// RevokeToken godoc
+//
+//	@Summary		Revoke a personal access token
+//	@Description	Revoke one of current user's tokens, it stops working immediately.
+//	@Tags			Access Token
+//	@Param			token_id	path	int	true	"token's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/users/me/tokens/{token_id} [delete]
+func (handler *accessTokenHandlerImpl) RevokeToken(c *gin.Context) {
+	tokenID := c.Param("token_id")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "revoke token", "you're not allowed to access this path", nil)
+		return
+	}
+
+	ucErr := handler.uc.RevokeToken(c, username, tokenID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "revoke token", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "revoke token", nil, nil)
+}
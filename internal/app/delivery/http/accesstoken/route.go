@@ -0,0 +1,26 @@
+package accesstoken
+
+import (
+	accesstokenhandler "goproject/internal/app/delivery/http/accesstoken/handler"
+	"goproject/internal/app/delivery/http/middlewares"
+	accesstokenrepository "goproject/internal/app/repository/accesstoken"
+	accesstokenusecase "goproject/internal/app/usecase/accesstoken"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	repository := accesstokenrepository.NewAccessTokenRepository(db)
+	usecase := accesstokenusecase.NewAccessTokenUsecase(repository, logger)
+	handler := accesstokenhandler.NewAccessTokenHandler(usecase)
+
+	// session only, a leaked token mustn't be able to mint new ones
+	token := r.Group("/users/me/tokens", middlewares.JWTAuthMiddleware(db))
+	{
+		token.POST("", handler.CreateToken)
+		token.GET("", handler.GetMyTokens)
+		token.DELETE("/:token_id", handler.RevokeToken)
+	}
+}
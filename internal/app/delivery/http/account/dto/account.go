@@ -0,0 +1,107 @@
+package dto
+
+import "time"
+
+type DeletionResponse struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// Export is everything a user put on the platform, each part is written to its own JSON file of the archive.
+type Export struct {
+	Profile  ExportProfile   `json:"profile"`
+	Blog     *ExportBlog     `json:"blog"`
+	Posts    []ExportPost    `json:"posts"`
+	Comments []ExportComment `json:"comments"`
+	Lists    []ExportList    `json:"lists"`
+	Media    []ExportMedia   `json:"media"`
+}
+
+type ExportProfile struct {
+	Username            string             `json:"username"`
+	Name                string             `json:"name"`
+	Email               string             `json:"email"`
+	EmailVerifiedAt     *time.Time         `json:"email_verified_at"`
+	Role                string             `json:"role"`
+	TwoFactor           bool               `json:"two_factor_enabled"`
+	DeletionScheduledAt *time.Time         `json:"deletion_scheduled_at,omitempty"`
+	Bio                 string             `json:"bio"`
+	Website             string             `json:"website"`
+	Location            string             `json:"location"`
+	SocialLinks         []ExportSocialLink `json:"social_links"`
+	AvatarMediaID       *uint              `json:"avatar_media_id"` // one of the files in media.json
+	ShowLocation        bool               `json:"show_location"`
+	ShowFollows         bool               `json:"show_follows"`
+	ShowActivity        bool               `json:"show_activity"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at"`
+}
+
+type ExportSocialLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+type ExportBlog struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ExportPost struct {
+	Title       string           `json:"title"`
+	Slug        string           `json:"post_slug"`
+	Content     string           `json:"content"` // markdown source
+	Status      string           `json:"status"`
+	Tags        []string         `json:"tags"`
+	PublishedAt *time.Time       `json:"published_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Revisions   []ExportRevision `json:"revisions"`
+}
+
+type ExportRevision struct {
+	Revision     uint      `json:"revision"`
+	Title        string    `json:"title"`
+	Content      string    `json:"content"`
+	RestoredFrom *uint     `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ExportComment struct {
+	ID        uint      `json:"comment_id"`
+	ParentID  *uint     `json:"parent_id,omitempty"`
+	PostURL   string    `json:"post_url"`
+	Comment   string    `json:"comment"`
+	Hidden    bool      `json:"hidden,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ExportList struct {
+	Name        string    `json:"name"`
+	Slug        string    `json:"list_slug"`
+	Description string    `json:"description"`
+	IsPublic    bool      `json:"is_public"`
+	PostURLs    []string  `json:"post_urls"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ExportMedia struct {
+	ID          uint              `json:"media_id"`
+	Filename    string            `json:"filename"`
+	ContentType string            `json:"content_type"`
+	Status      string            `json:"status"`
+	Width       int               `json:"width,omitempty"`
+	Height      int               `json:"height,omitempty"`
+	Files       []ExportMediaFile `json:"files"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// ExportMediaFile is one of the media's files, Path is where it is in the archive.
+type ExportMediaFile struct {
+	Path  string `json:"path"`
+	Width int    `json:"width,omitempty"`
+	Key   string `json:"-"`
+}
@@ -0,0 +1,119 @@
+package accounthandler
+
+import (
+	"fmt"
+	accountusecase "goproject/internal/app/usecase/account"
+	"goproject/internal/helpers"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler interface {
+	ExportData(c *gin.Context)
+	DeleteAccount(c *gin.Context)
+	CancelDeletion(c *gin.Context)
+}
+
+type accountHandlerImpl struct {
+	uc accountusecase.AccountUsecase
+}
+
+func NewAccountHandler(uc accountusecase.AccountUsecase) AccountHandler {
+	return &accountHandlerImpl{
+		uc: uc,
+	}
+}
+
+// ExportData godoc
+//
+//	@Summary		Download current user's data
+//	@Description	Download a zip archive of everything current user has: profile, blog, posts with their revisions, comments, lists and uploaded files.
+//	@Description	Each of them is in a JSON file, and posts and revisions are also in Markdown files with a front matter.
+//	@Tags			User
+//	@Security		BearerToken
+//	@Produce		application/zip
+//	@Success		200
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Router			/users/me/export [get]
+func (handler *accountHandlerImpl) ExportData(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "export data", "you're not allowed to access this path", nil)
+		return
+	}
+
+	export, ucErr := handler.uc.ExportData(c, username)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "export data", ucErr.String(), nil)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.zip", username, time.Now().Format("2006-01-02"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	// the status is already sent, a failure can only cut the archive short, which makes it unreadable
+	_ = handler.uc.WriteExport(c, *export, c.Writer)
+}
+
+// DeleteAccount godoc
+//
+//	@Summary		Delete current user's account
+//	@Description	Schedule current user's account for deletion once the grace period (14 days by default) is over, an email is sent to confirm it.
+//	@Description	Until then the account works as usual and the deletion can be cancelled. The blog, posts, lists and files are then deleted,
+//	@Description	the posts disappear from other users' lists too, and comments stay without their author.
+//	@Tags			User
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		202	{object}	helpers.ResponseWithData{data=dto.DeletionResponse}
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/users/me [delete]
+func (handler *accountHandlerImpl) DeleteAccount(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "delete account", "you're not allowed to access this path", nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.ScheduleDeletion(c, username)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "delete account", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusAccepted, "delete account", nil, resp)
+}
+
+// CancelDeletion godoc
+//
+//	@Summary		Cancel current user's account deletion
+//	@Description	Keep current user's account, as long as its deletion hasn't happened yet.
+//	@Tags			User
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/users/me/cancel-deletion [post]
+func (handler *accountHandlerImpl) CancelDeletion(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "cancel account deletion", "you're not allowed to access this path", nil)
+		return
+	}
+
+	ucErr := handler.uc.CancelDeletion(c, username)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "cancel account deletion", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "cancel account deletion", nil, nil)
+}
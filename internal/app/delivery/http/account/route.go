@@ -0,0 +1,22 @@
+package account
+
+import (
+	accounthandler "goproject/internal/app/delivery/http/account/handler"
+	"goproject/internal/app/delivery/http/middlewares"
+	accountusecase "goproject/internal/app/usecase/account"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, usecase accountusecase.AccountUsecase) {
+	handler := accounthandler.NewAccountHandler(usecase)
+
+	// only login sessions, a personal access token is never enough to take everything or delete the account
+	account := r.Group("/users/me", middlewares.JWTAuthMiddleware(db))
+	{
+		account.GET("/export", handler.ExportData)
+		account.DELETE("", handler.DeleteAccount)
+		account.POST("/cancel-deletion", handler.CancelDeletion)
+	}
+}
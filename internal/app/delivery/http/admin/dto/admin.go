@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user moderator admin"`
+}
+
+type ReportResponse struct {
+	ID         uint       `json:"report_id"`
+	Reporter   string     `json:"reporter"`
+	PostURL    string     `json:"post_url"`
+	CommentID  *uint      `json:"comment_id,omitempty"`
+	Comment    string     `json:"comment,omitempty"`
+	Reason     string     `json:"reason"`
+	ResolvedBy *string    `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
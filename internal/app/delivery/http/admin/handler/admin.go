@@ -0,0 +1,308 @@
+package adminhandler
+
+import (
+	"goproject/internal/app/delivery/http/admin/dto"
+	adminusecase "goproject/internal/app/usecase/admin"
+	"goproject/internal/domain/policy"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler interface {
+	SuspendUser(c *gin.Context)
+	UnsuspendUser(c *gin.Context)
+	UnlockUser(c *gin.Context)
+	ChangeRole(c *gin.Context)
+	RemovePost(c *gin.Context)
+	RemoveComment(c *gin.Context)
+	HideComment(c *gin.Context)
+	UnhideComment(c *gin.Context)
+	GetReports(c *gin.Context)
+	ResolveReport(c *gin.Context)
+}
+
+type adminHandlerImpl struct {
+	uc adminusecase.AdminUsecase
+}
+
+func NewAdminHandler(uc adminusecase.AdminUsecase) AdminHandler {
+	return &adminHandlerImpl{
+		uc: uc,
+	}
+}
+
+//	@SuspendUser	godoc
+//	@Summary		Suspend a user
+//	@Description	Suspend a user, they're logged out everywhere and can't log in until the suspension is lifted.
+//	@Description	Admins can't be suspended. Only admins can access this path.
+//	@Tags			Admin
+//	@Param			username	path	string	true	"username of the user to suspend"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		403	{object}	helpers.ResponseWithError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/users/{username}/suspension [post]
+func (handler *adminHandlerImpl) SuspendUser(c *gin.Context) {
+	target := c.Param("username")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "suspend user", "you're not allowed to access this path", nil)
+		return
+	}
+
+	actor := policy.Actor{Username: username, Role: c.GetString("role")}
+
+	ucErr := handler.uc.SuspendUser(c, actor, target)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "suspend user", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "suspend user", nil, nil)
+}
+
+//	@UnsuspendUser	godoc
+//	@Summary		Lift a user's suspension
+//	@Description	Lift a user's suspension so they can log in again. Only admins can access this path.
+//	@Tags			Admin
+//	@Param			username	path	string	true	"username of the suspended user"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/users/{username}/suspension [delete]
+func (handler *adminHandlerImpl) UnsuspendUser(c *gin.Context) {
+	target := c.Param("username")
+
+	ucErr := handler.uc.UnsuspendUser(c, target)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "unsuspend user", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "unsuspend user", nil, nil)
+}
+
+//	@UnlockUser		godoc
+//	@Summary		Lift a user's login lockout
+//	@Description	Let a user who got locked out after too many failed logins try again right away. Only admins can access this path.
+//	@Tags			Admin
+//	@Param			username	path	string	true	"username of the locked user"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/users/{username}/lockout [delete]
+func (handler *adminHandlerImpl) UnlockUser(c *gin.Context) {
+	target := c.Param("username")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "unlock user", "you're not allowed to access this path", nil)
+		return
+	}
+
+	actor := policy.Actor{Username: username, Role: c.GetString("role")}
+
+	ucErr := handler.uc.UnlockUser(c, actor, target, c.ClientIP())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "unlock user", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "unlock user", nil, nil)
+}
+
+//	@ChangeRole		godoc
+//	@Summary		Change a user's role
+//	@Description	Change a user's role to user, moderator or admin. The new role applies once the user's token is refreshed.
+//	@Description	Only admins can access this path, and they can't change their own role.
+//	@Tags			Admin
+//	@Param			username	path	string					true	"username of the user"
+//	@Param			Body		body	dto.ChangeRoleRequest	true	"the new role"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/users/{username}/role [put]
+func (handler *adminHandlerImpl) ChangeRole(c *gin.Context) {
+	var data dto.ChangeRoleRequest
+	target := c.Param("username")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "change role", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "change role", helpers.ValidationError(err), nil)
+		return
+	}
+
+	actor := policy.Actor{Username: username, Role: c.GetString("role")}
+
+	ucErr := handler.uc.ChangeRole(c, actor, target, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "change role", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "change role", nil, nil)
+}
+
+//	@RemovePost		godoc
+//	@Summary		Remove any post
+//	@Description	Remove a post from any blog, along with its comments. Only admins can access this path.
+//	@Tags			Admin
+//	@Param			username	path	string	true	"blog owner's username"
+//	@Param			post_slug	path	string	true	"post's slug"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/blog/{username}/posts/{post_slug} [delete]
+func (handler *adminHandlerImpl) RemovePost(c *gin.Context) {
+	blogOwner := c.Param("username")
+	postSlug := c.Param("post_slug")
+
+	ucErr := handler.uc.RemovePost(c, blogOwner, postSlug)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "remove post", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "remove post", nil, nil)
+}
+
+//	@RemoveComment	godoc
+//	@Summary		Remove any comment
+//	@Description	Remove a comment by its ID, wherever it was posted.
+//	@Description	A comment that already has replies is replaced by a "[deleted]" placeholder instead of being removed with its replies.
+//	@Description	Only admins can access this path.
+//	@Tags			Admin
+//	@Param			comment_id	path	int	true	"comment's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/comments/{comment_id} [delete]
+func (handler *adminHandlerImpl) RemoveComment(c *gin.Context) {
+	commentID := c.Param("comment_id")
+
+	ucErr := handler.uc.RemoveComment(c, commentID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "remove comment", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "remove comment", nil, nil)
+}
+
+//	@HideComment	godoc
+//	@Summary		Hide a comment
+//	@Description	Hide a comment site-wide, it's shown as "[hidden]" and can't be replied to. Moderators and admins can access this path.
+//	@Tags			Admin
+//	@Param			comment_id	path	int	true	"comment's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/comments/{comment_id}/hidden [post]
+func (handler *adminHandlerImpl) HideComment(c *gin.Context) {
+	commentID := c.Param("comment_id")
+
+	ucErr := handler.uc.HideComment(c, commentID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "hide comment", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "hide comment", nil, nil)
+}
+
+//	@UnhideComment	godoc
+//	@Summary		Unhide a comment
+//	@Description	Make a hidden comment visible again. Moderators and admins can access this path.
+//	@Tags			Admin
+//	@Param			comment_id	path	int	true	"comment's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/admin/comments/{comment_id}/hidden [delete]
+func (handler *adminHandlerImpl) UnhideComment(c *gin.Context) {
+	commentID := c.Param("comment_id")
+
+	ucErr := handler.uc.UnhideComment(c, commentID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "unhide comment", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "unhide comment", nil, nil)
+}
+
+//	@GetReports		godoc
+//	@Summary		Get reports
+//	@Description	Get reports on posts and comments, newest first. Moderators and admins can access this path.
+//	@Tags			Admin
+//	@Param			status	query	string	false	"open (default) or resolved"
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.ReportResponse}
+//	@Router			/admin/reports [get]
+func (handler *adminHandlerImpl) GetReports(c *gin.Context) {
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get reports", err.Error(), nil)
+		return
+	}
+
+	reports, pagination, ucErr := handler.uc.GetReports(c, c.Query("status"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get reports", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get reports", reports, pagination)
+}
+
+//	@ResolveReport	godoc
+//	@Summary		Resolve a report
+//	@Description	Mark a report as resolved once it has been dealt with. Moderators and admins can access this path.
+//	@Tags			Admin
+//	@Param			report_id	path	int	true	"report's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/admin/reports/{report_id}/resolve [post]
+func (handler *adminHandlerImpl) ResolveReport(c *gin.Context) {
+	reportID := c.Param("report_id")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "resolve report", "you're not allowed to access this path", nil)
+		return
+	}
+
+	actor := policy.Actor{Username: username, Role: c.GetString("role")}
+
+	ucErr := handler.uc.ResolveReport(c, actor, reportID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "resolve report", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "resolve report", nil, nil)
+}
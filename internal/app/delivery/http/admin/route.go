@@ -0,0 +1,42 @@
+package admin
+
+import (
+	adminhandler "goproject/internal/app/delivery/http/admin/handler"
+	"goproject/internal/app/delivery/http/middlewares"
+	commentrepository "goproject/internal/app/repository/comment"
+	postrepository "goproject/internal/app/repository/post"
+	reportrepository "goproject/internal/app/repository/report"
+	sessionrepository "goproject/internal/app/repository/session"
+	userrepository "goproject/internal/app/repository/user"
+	adminusecase "goproject/internal/app/usecase/admin"
+	lockoutusecase "goproject/internal/app/usecase/lockout"
+	"goproject/internal/domain/policy"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, lockout lockoutusecase.LockoutUsecase, logger *slog.Logger) {
+	userRepository := userrepository.NewUserRepository(db)
+	sessionRepository := sessionrepository.NewSessionRepository(db)
+	postRepository := postrepository.NewPostRepository(db)
+	commentRepository := commentrepository.NewCommentRepository(db)
+	reportRepository := reportrepository.NewReportRepository(db)
+	usecase := adminusecase.NewAdminUsecase(userRepository, sessionRepository, postRepository, commentRepository, reportRepository, lockout, logger)
+	handler := adminhandler.NewAdminHandler(usecase)
+
+	admin := r.Group("/admin", middlewares.JWTAuthMiddleware(db))
+	{
+		admin.POST("/users/:username/suspension", middlewares.RequirePermission(policy.SuspendUser), handler.SuspendUser)
+		admin.DELETE("/users/:username/suspension", middlewares.RequirePermission(policy.SuspendUser), handler.UnsuspendUser)
+		admin.DELETE("/users/:username/lockout", middlewares.RequirePermission(policy.UnlockUser), handler.UnlockUser)
+		admin.PUT("/users/:username/role", middlewares.RequirePermission(policy.ChangeRole), handler.ChangeRole)
+		admin.DELETE("/blog/:username/posts/:post_slug", middlewares.RequirePermission(policy.RemoveAnyPost), handler.RemovePost)
+		admin.DELETE("/comments/:comment_id", middlewares.RequirePermission(policy.RemoveAnyComment), handler.RemoveComment)
+		admin.POST("/comments/:comment_id/hidden", middlewares.RequirePermission(policy.HideComment), handler.HideComment)
+		admin.DELETE("/comments/:comment_id/hidden", middlewares.RequirePermission(policy.HideComment), handler.UnhideComment)
+		admin.GET("/reports", middlewares.RequirePermission(policy.ViewReports), handler.GetReports)
+		admin.POST("/reports/:report_id/resolve", middlewares.RequirePermission(policy.ResolveReport), handler.ResolveReport)
+	}
+}
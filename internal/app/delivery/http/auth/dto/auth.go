@@ -12,6 +12,51 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// LoginResponse either carries the tokens or, when the account has 2FA, only a challenge token for the second step.
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token             string `json:"token,omitempty"`
+	RefreshToken      string `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,password,min=8,max=32"`
+}
+
+type UnlockRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// OIDCCallbackRequest is what the identity provider sends the user back with, either a code or an error.
+type OIDCCallbackRequest struct {
+	State            string `form:"state" binding:"required"`
+	Code             string `form:"code"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// MagicLinkRegisterRequest is RegisterRequest without the password, the account logs in through emailed links.
+type MagicLinkRegisterRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Username string `json:"username" binding:"required,min=6"`
+	Name     string `json:"name" binding:"required"`
 }
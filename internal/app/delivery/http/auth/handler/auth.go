@@ -12,6 +12,17 @@ import (
 type UserHandler interface {
 	Register(c *gin.Context)
 	Login(c *gin.Context)
+	Refresh(c *gin.Context)
+	Logout(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
+	LoginTwoFactor(c *gin.Context)
+	UnlockAccount(c *gin.Context)
+	LoginOIDC(c *gin.Context)
+	OIDCCallback(c *gin.Context)
+	SendMagicLink(c *gin.Context)
+	RegisterWithMagicLink(c *gin.Context)
+	LoginMagicLink(c *gin.Context)
 }
 
 type userHandlerImpl struct {
@@ -29,6 +40,7 @@ func NewAuthHandler(usecase authusecase.AuthUsecase) UserHandler {
 //	@Summary		Create a new account
 //	@Description	Create a new account by providing required data. This will automatically create a blog named: "<user's name>'s blog".
 //	@Description	User's username and email must be unique. Meaning that there can't be 2 users using the same email/username.
+//	@Description	A verification link is sent to the email, the account can't write posts or comments until it's opened.
 //	@Tags			Auth
 //	@Param			Body	body	dto.RegisterRequest	true	"data required to create a new account"
 //	@Produce		json
@@ -45,7 +57,7 @@ func (handler *userHandlerImpl) Register(c *gin.Context) {
 		return
 	}
 
-	ucErr := handler.uc.Register(c, data)
+	ucErr := handler.uc.Register(c, data, helpers.APIURL("/auth/verify"))
 	if ucErr != nil {
 		helpers.ResponseBuilder(c, ucErr.Code, "register", ucErr.String(), nil)
 		return
@@ -59,13 +71,16 @@ func (handler *userHandlerImpl) Register(c *gin.Context) {
 //	@Summary		Login as an existing user
 //	@Description	Log in as an existing user by providing a username and password
 //
-//	@Description	Upon successful login, a JWT will be provided
+//	@Description	Upon successful login, a short-lived JWT and a refresh token will be provided
+//	@Description	If the account has two-factor authentication enabled, only a challenge token is provided, to be sent with a code to /auth/login/2fa
+//	@Description	After a few failed attempts, the username and the IP address have to wait longer and longer between attempts, and are locked for a while after too many.
 //
 //	@Tags			Auth
 //	@Param			Body	body	dto.LoginRequest	true	"data required to login to an existing account"
 //	@Produce		json
 //	@Success		200	{object}	helpers.ResponseWithData{data=dto.LoginResponse}
 //	@Failure		401	{object}	helpers.ResponseWithError
+//	@Failure		429	{object}	helpers.ResponseWithError
 //	@Router			/auth/login [post]
 func (handler *userHandlerImpl) Login(c *gin.Context) {
 	var data dto.LoginRequest
@@ -76,7 +91,316 @@ func (handler *userHandlerImpl) Login(c *gin.Context) {
 		return
 	}
 
-	resp, ucErr := handler.uc.Login(c, data)
+	resp, ucErr := handler.uc.Login(c, data, c.ClientIP())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "login", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "login", nil, resp)
+}
+
+// LoginTwoFactor godoc
+//
+//	@Summary		Finish logging in with a two-factor code
+//	@Description	Second step of logging in to an account with two-factor authentication.
+//	@Description	The code is either from the authenticator app or one of the recovery codes. The challenge token can only be used once, even with a wrong code.
+//	@Tags			Auth
+//	@Param			Body	body	dto.TwoFactorLoginRequest	true	"challenge token from login and a two-factor code"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.LoginResponse}
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Failure		429	{object}	helpers.ResponseWithError
+//	@Router			/auth/login/2fa [post]
+func (handler *userHandlerImpl) LoginTwoFactor(c *gin.Context) {
+	var data dto.TwoFactorLoginRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "login", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.LoginTwoFactor(c, data, c.ClientIP())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "login", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "login", nil, resp)
+}
+
+// RefreshToken godoc
+//
+//	@Summary		Get a new access token
+//	@Description	Exchange a refresh token for a new JWT and a new refresh token.
+//	@Description	A refresh token can only be used once. Using an already used refresh token will revoke the whole session.
+//	@Tags			Auth
+//	@Param			Body	body	dto.RefreshRequest	true	"refresh token received from login or a previous refresh"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.LoginResponse}
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Router			/auth/refresh [post]
+func (handler *userHandlerImpl) Refresh(c *gin.Context) {
+	var data dto.RefreshRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "refresh token", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.Refresh(c, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "refresh token", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "refresh token", nil, resp)
+}
+
+// UserLogout godoc
+//
+//	@Summary		Logout from current session
+//	@Description	Revoke the session the current JWT belongs to. The JWT and every refresh token of this session will stop working.
+//	@Tags			Auth
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Router			/auth/logout [post]
+func (handler *userHandlerImpl) Logout(c *gin.Context) {
+	sessionID := c.GetUint("session_id")
+
+	if sessionID == 0 {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "logout", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := handler.uc.Logout(c, sessionID)
+	if err != nil {
+		helpers.ResponseBuilder(c, err.Code, "logout", err.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "logout", nil, nil)
+}
+
+// ForgotPassword godoc
+//
+//	@Summary		Ask for a password reset token
+//	@Description	Send a single-use password reset token to the given email, it expires in 30 minutes.
+//	@Description	The response is the same whether an account uses the email or not.
+//	@Tags			Auth
+//	@Param			Body	body	dto.ForgotPasswordRequest	true	"email of the account"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		400	{object}	helpers.ResponseWithError{error=[]helpers.InputError}
+//	@Router			/auth/forgot-password [post]
+func (handler *userHandlerImpl) ForgotPassword(c *gin.Context) {
+	var data dto.ForgotPasswordRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "forgot password", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.ForgotPassword(c, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "forgot password", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "forgot password", nil, nil)
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset password
+//	@Description	Set a new password using the token sent by forgot-password. Every session and personal access token of the account is revoked along with it.
+//	@Tags			Auth
+//	@Param			Body	body	dto.ResetPasswordRequest	true	"reset token and the new password"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		400	{object}	helpers.ResponseWithError
+//	@Router			/auth/reset-password [post]
+func (handler *userHandlerImpl) ResetPassword(c *gin.Context) {
+	var data dto.ResetPasswordRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "reset password", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.ResetPassword(c, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "reset password", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "reset password", nil, nil)
+}
+
+// UnlockAccount godoc
+//
+//	@Summary		Unlock a locked account
+//	@Description	Lift a login lockout early, using the token emailed to the account's owner when it was locked.
+//	@Tags			Auth
+//	@Param			Body	body	dto.UnlockRequest	true	"unlock token"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		400	{object}	helpers.ResponseWithError
+//	@Router			/auth/unlock [post]
+func (handler *userHandlerImpl) UnlockAccount(c *gin.Context) {
+	var data dto.UnlockRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "unlock account", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.UnlockAccount(c, data, c.ClientIP())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "unlock account", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "unlock account", nil, nil)
+}
+
+// LoginOIDC godoc
+//
+//	@Summary		Log in with single sign-on
+//	@Description	Redirect to the OpenID Connect identity provider to log in there. It sends the user back to /auth/oidc/callback.
+//	@Tags			Auth
+//	@Success		302
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Failure		502	{object}	helpers.ResponseWithError
+//	@Router			/auth/oidc/login [get]
+func (handler *userHandlerImpl) LoginOIDC(c *gin.Context) {
+	authURL, ucErr := handler.uc.StartOIDCLogin(c, helpers.APIURL("/auth/oidc/callback"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "login", ucErr.String(), nil)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback godoc
+//
+//	@Summary		Finish logging in with single sign-on
+//	@Description	Where the identity provider sends the user back to. The identity is linked to the account with the same verified email,
+//	@Description	or a new account, along with its blog, is created for it. Like with a password, accounts with 2FA only get a challenge token.
+//	@Tags			Auth
+//	@Param			state				query	string	true	"state given to the identity provider"
+//	@Param			code				query	string	false	"authorization code"
+//	@Param			error				query	string	false	"why the identity provider refused the login"
+//	@Param			error_description	query	string	false	"details of the error"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.LoginResponse}
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Failure		403	{object}	helpers.ResponseWithError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/auth/oidc/callback [get]
+func (handler *userHandlerImpl) OIDCCallback(c *gin.Context) {
+	var data dto.OIDCCallbackRequest
+
+	err := c.ShouldBindQuery(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "login", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.LoginOIDC(c, data, helpers.APIURL("/auth/oidc/callback"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "login", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "login", nil, resp)
+}
+
+func magicLinkURL() string {
+	return helpers.APIURL("/auth/magic-link/login")
+}
+
+// SendMagicLink godoc
+//
+//	@Summary		Ask for a sign-in link
+//	@Description	Email a single-use sign-in link to the account with the given email, it expires in 15 minutes and links sent before stop working.
+//	@Description	The response is the same whether an account uses the email or not. At most 5 links are sent to an account per hour, further requests are silently ignored.
+//	@Tags			Auth
+//	@Param			Body	body	dto.MagicLinkRequest	true	"email of the account"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		400	{object}	helpers.ResponseWithError{error=[]helpers.InputError}
+//	@Router			/auth/magic-link [post]
+func (handler *userHandlerImpl) SendMagicLink(c *gin.Context) {
+	var data dto.MagicLinkRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "send sign-in link", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.SendMagicLink(c, data, magicLinkURL())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "send sign-in link", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "send sign-in link", nil, nil)
+}
+
+// RegisterWithMagicLink godoc
+//
+//	@Summary		Create a new account without a password
+//	@Description	Create a new account, along with its blog, that logs in through sign-in links instead of a password. A sign-in link is sent right away,
+//	@Description	opening it also verifies the email. A password can be set later through forgot-password.
+//	@Tags			Auth
+//	@Param			Body	body	dto.MagicLinkRegisterRequest	true	"data required to create a new account"
+//	@Produce		json
+//	@Success		201	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Failure		400	{object}	helpers.ResponseWithError{error=[]helpers.InputError}
+//	@Router			/auth/magic-link/register [post]
+func (handler *userHandlerImpl) RegisterWithMagicLink(c *gin.Context) {
+	var data dto.MagicLinkRegisterRequest
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "register", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.RegisterWithMagicLink(c, data, magicLinkURL())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "register", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusCreated, "register", nil, nil)
+}
+
+// LoginMagicLink godoc
+//
+//	@Summary		Log in with a sign-in link
+//	@Description	Where sign-in links point to. Gives the same tokens as logging in with a password, accounts with 2FA only get a challenge token.
+//	@Description	The link can only be used once.
+//	@Tags			Auth
+//	@Param			token	query	string	true	"sign-in token"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.LoginResponse}
+//	@Failure		400	{object}	helpers.ResponseWithError
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Failure		403	{object}	helpers.ResponseWithError
+//	@Router			/auth/magic-link/login [get]
+func (handler *userHandlerImpl) LoginMagicLink(c *gin.Context) {
+	resp, ucErr := handler.uc.LoginMagicLink(c, c.Query("token"))
 	if ucErr != nil {
 		helpers.ResponseBuilder(c, ucErr.Code, "login", ucErr.String(), nil)
 		return
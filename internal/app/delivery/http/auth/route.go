@@ -2,9 +2,21 @@ package auth
 
 import (
 	authhandler "goproject/internal/app/delivery/http/auth/handler"
+	"goproject/internal/app/delivery/http/middlewares"
 	blogrepository "goproject/internal/app/repository/blog"
+	oidcauthrequestrepository "goproject/internal/app/repository/oidcauthrequest"
+	recoverycoderepository "goproject/internal/app/repository/recoverycode"
+	sessionrepository "goproject/internal/app/repository/session"
 	userrepository "goproject/internal/app/repository/user"
+	useridentityrepository "goproject/internal/app/repository/useridentity"
+	usernamealiasrepository "goproject/internal/app/repository/usernamealias"
+	usertokenrepository "goproject/internal/app/repository/usertoken"
 	authusecase "goproject/internal/app/usecase/auth"
+	lockoutusecase "goproject/internal/app/usecase/lockout"
+	twofactorusecase "goproject/internal/app/usecase/twofactor"
+	verificationusecase "goproject/internal/app/usecase/verification"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/infrastructure/oidc"
 
 	"log/slog"
 
@@ -12,10 +24,18 @@ import (
 	"gorm.io/gorm"
 )
 
-func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+func Route(r *gin.RouterGroup, db *gorm.DB, mailer mail.Mailer, lockout lockoutusecase.LockoutUsecase, logger *slog.Logger) {
 	userRepository := userrepository.NewUserRepository(db)
 	blogRepository := blogrepository.NewBlogRepository(db)
-	usecase := authusecase.NewAuthUsecase(userRepository, blogRepository, db, logger)
+	sessionRepository := sessionrepository.NewSessionRepository(db)
+	userTokenRepository := usertokenrepository.NewUserTokenRepository(db)
+	oidcProvider, err := oidc.NewProvider()
+	if err != nil {
+		panic(err)
+	}
+	verifier := verificationusecase.NewVerificationUsecase(userRepository, userTokenRepository, mailer, logger)
+	twoFactor := twofactorusecase.NewTwoFactorUsecase(userRepository, recoverycoderepository.NewRecoveryCodeRepository(db), logger)
+	usecase := authusecase.NewAuthUsecase(userRepository, blogRepository, sessionRepository, userTokenRepository, useridentityrepository.NewUserIdentityRepository(db), oidcauthrequestrepository.NewOIDCAuthRequestRepository(db), usernamealiasrepository.NewUsernameAliasRepository(db), verifier, twoFactor, lockout, oidcProvider, mailer, db, logger)
 	handler := authhandler.NewAuthHandler(usecase)
 
 	auth := r.Group("/auth")
@@ -23,5 +43,16 @@ func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
 	{
 		auth.POST("/register", handler.Register)
 		auth.POST("/login", handler.Login)
+		auth.POST("/login/2fa", handler.LoginTwoFactor)
+		auth.POST("/refresh", handler.Refresh)
+		auth.POST("/logout", middlewares.JWTAuthMiddleware(db), handler.Logout)
+		auth.POST("/forgot-password", handler.ForgotPassword)
+		auth.POST("/reset-password", handler.ResetPassword)
+		auth.POST("/unlock", handler.UnlockAccount)
+		auth.GET("/oidc/login", handler.LoginOIDC)
+		auth.GET("/oidc/callback", handler.OIDCCallback)
+		auth.POST("/magic-link", handler.SendMagicLink)
+		auth.POST("/magic-link/register", handler.RegisterWithMagicLink)
+		auth.GET("/magic-link/login", handler.LoginMagicLink)
 	}
 }
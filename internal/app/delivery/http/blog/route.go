@@ -5,6 +5,7 @@ import (
 	"goproject/internal/app/delivery/http/middlewares"
 	blogrepository "goproject/internal/app/repository/blog"
 	blogusecase "goproject/internal/app/usecase/blog"
+	"goproject/internal/domain/policy"
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
@@ -18,11 +19,11 @@ func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
 
 	blog := r.Group("/blog")
 	{
-		myBlog := blog.Group("/my").Use(middlewares.JWTAuthMiddleware())
+		myBlog := blog.Group("/my")
 		{
-			myBlog.PUT("", handler.UpdateBlogData)
-			myBlog.GET("", handler.GetMyBlog)
+			myBlog.PUT("", middlewares.JWTAuthMiddleware(db, policy.ScopeBlogWrite), handler.UpdateBlogData)
+			myBlog.GET("", middlewares.JWTAuthMiddleware(db, policy.ScopeProfileRead), handler.GetMyBlog)
 		}
-		blog.GET("/:username", handler.GetBlogByOwner)
+		blog.GET("/:username", middlewares.RedirectUsernameAlias(db), handler.GetBlogByOwner)
 	}
 }
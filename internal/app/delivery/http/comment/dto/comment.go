@@ -11,10 +11,15 @@ type CreateCommentResponse struct {
 }
 
 type CommentResponse struct {
-	ID        uint      `json:"comment_id"`
-	PostURL   string    `json:"post_url,omitempty"`
-	Commenter string    `json:"commenter,omitempty"`
-	Comment   string    `json:"comment"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           uint               `json:"comment_id"`
+	ParentID     *uint              `json:"parent_id,omitempty"`
+	PostURL      string             `json:"post_url,omitempty"`
+	Commenter    string             `json:"commenter,omitempty"`
+	Comment      string             `json:"comment"`
+	Deleted      bool               `json:"deleted,omitempty"`
+	Hidden       bool               `json:"hidden,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	NumOfReplies *int64             `json:"num_of_replies,omitempty"`
+	Replies      *[]CommentResponse `json:"replies,omitempty"` // nil once max_depth is reached, even when num_of_replies isn't 0
 }
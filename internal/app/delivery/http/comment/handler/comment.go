@@ -3,6 +3,7 @@ package commenthandler
 import (
 	"goproject/internal/app/delivery/http/comment/dto"
 	commentusecase "goproject/internal/app/usecase/comment"
+	"goproject/internal/domain/policy"
 	"goproject/internal/helpers"
 	"net/http"
 
@@ -11,6 +12,7 @@ import (
 
 type CommentHandler interface {
 	CreateComment(c *gin.Context)
+	ReplyToComment(c *gin.Context)
 	GetMyComments(c *gin.Context)
 	GetCommentsOnAPost(c *gin.Context)
 	DeleteCommentByID(c *gin.Context)
@@ -38,6 +40,7 @@ func NewCommentHandler(uc commentusecase.CommentUsecase) CommentHandler {
 //	@Security		BearerToken
 //	@Produce		json
 //	@Success		201	{object}	helpers.ResponseWithData{data=dto.CreateCommentResponse}
+//	@Failure		403	{object}	helpers.ResponseWithError
 //	@Failure		404	{object}	helpers.ResponseWithError
 //	@Router			/blog/{username}/posts/{post_slug}/comments [post]
 func (handler *commentHandlerImpl) CreateComment(c *gin.Context) {
@@ -66,13 +69,58 @@ func (handler *commentHandlerImpl) CreateComment(c *gin.Context) {
 	helpers.ResponseBuilder(c, http.StatusCreated, "create comment", nil, commentID)
 }
 
+//	@ReplyToComment	godoc
+//	@Summary		Reply to a comment
+//	@Description	Create a new comment on a blog post as a reply to another comment.
+//	@Description	Upon successful creation, it will returns the newly created comment's ID.
+//	@Tags			Comment
+//	@Param			Body		body	dto.CommentRequest	true	"data required to create a comment"
+//	@Param			username	path	string				true	"blog owner's username"
+//	@Param			post_slug	path	string				true	"post's slug"
+//	@Param			comment_id	path	int					true	"ID of the comment you want to reply to"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		201	{object}	helpers.ResponseWithData{data=dto.CreateCommentResponse}
+//	@Failure		403	{object}	helpers.ResponseWithError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/blog/{username}/posts/{post_slug}/comments/{comment_id}/replies [post]
+func (handler *commentHandlerImpl) ReplyToComment(c *gin.Context) {
+	var data dto.CommentRequest
+	blogOwner := c.Param("username")
+	postSlug := c.Param("post_slug")
+	commentID := c.Param("comment_id")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "reply to comment", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "reply to comment", helpers.ValidationError(err), nil)
+		return
+	}
+
+	replyID, ucErr := handler.uc.ReplyToComment(c, data, username, blogOwner, postSlug, commentID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "reply to comment", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusCreated, "reply to comment", nil, dto.CreateCommentResponse{ID: replyID})
+}
+
 //	@GetMyComments	Godoc
 //	@Summary		Get current user's comments
 //	@Description	Get current user's comments on all posts.
 //	@Tags			Comment
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
 //	@Security		BearerToken
 //	@Produce		json
-//	@Success		200	{object}	helpers.ResponseWithData{data=dto.CommentResponse}
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=dto.CommentResponse}
 //	@Router			/my/comments [get]
 func (handler *commentHandlerImpl) GetMyComments(c *gin.Context) {
 	username := c.GetString("username")
@@ -81,42 +129,61 @@ func (handler *commentHandlerImpl) GetMyComments(c *gin.Context) {
 		return
 	}
 
-	comments, err := handler.uc.GetCommentsByUsername(c, username)
+	page, err := helpers.ParsePageRequest(c)
 	if err != nil {
-		helpers.ResponseBuilder(c, err.Code, "get comments", err.String(), nil)
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get comments", err.Error(), nil)
 		return
 	}
 
-	helpers.ResponseBuilder(c, http.StatusOK, "get comments", nil, comments)
+	comments, pagination, ucErr := handler.uc.GetCommentsByUsername(c, username, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get comments", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get comments", comments, pagination)
 }
 
 //	@GetCommentOnAPost	Godoc
 //	@Summary			Get comments on a post
 //	@Description		Get all comments on a post by post's URL.
+//	@Description		Top-level comments are paginated, newest first. Each of them comes with its replies as a tree, oldest first, up to max_depth levels deep.
+//	@Description		Deleted comments that still have replies are shown as "[deleted]".
 //	@Tags				Comment
 //	@Param				username	path	string	true	"blog owner's username"
 //	@Param				post_slug	path	string	true	"post's slug"
+//	@Param				max_depth	query	int		false	"how many levels of replies to include, 3 by default and 10 at most"
+//	@Param				limit		query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param				after		query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param				before		query	string	false	"cursor of the previous page, taken from pagination.prev"
 //	@Produce			json
-//	@Success			200	{object}	helpers.ResponseWithData{data=[]dto.CommentResponse}
+//	@Success			200	{object}	helpers.ResponseWithPagination{data=[]dto.CommentResponse}
 //	@Router				/blog/{username}/posts/{post_slug}/comments [get]
 func (handler *commentHandlerImpl) GetCommentsOnAPost(c *gin.Context) {
 	blogOwner := c.Param("username")
 	postSlug := c.Param("post_slug")
 
-	comments, err := handler.uc.GetCommentsByBlogOwnerAndPostSlug(c, blogOwner, postSlug)
+	page, err := helpers.ParsePageRequest(c)
 	if err != nil {
-		helpers.ResponseBuilder(c, err.Code, "get comments", err.String(), nil)
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get comments", err.Error(), nil)
+		return
+	}
+
+	comments, pagination, ucErr := handler.uc.GetCommentsByBlogOwnerAndPostSlug(c, blogOwner, postSlug, c.Query("max_depth"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get comments", ucErr.String(), nil)
 		return
 	}
 
-	helpers.ResponseBuilder(c, http.StatusOK, "get comments", nil, comments)
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get comments", comments, pagination)
 }
 
 //	@DeleteCommentByID	Godoc
 //	@Summary			Delete a comment
 //	@Description		Delete a comment on a post by comment's ID.
 //	@Description		A non-blog-owner user can only delete their own comment.
-//	@Description		Blog's owner is allowed to delete ANY comment on their posts.
+//	@Description		Blog's owner is allowed to delete ANY comment on their posts, and so are admins on every post.
+//	@Description		A comment that already has replies is replaced by a "[deleted]" placeholder instead of being removed with its replies.
 //	@Tags				Comment
 //	@Param				username	path	string	true	"blog owner's username"
 //	@Param				post_slug	path	string	true	"post's slug"
@@ -137,7 +204,9 @@ func (handler *commentHandlerImpl) DeleteCommentByID(c *gin.Context) {
 		return
 	}
 
-	err := handler.uc.DeleteCommentOnAPosst(c, username, blogOwner, postSlug, commentID)
+	actor := policy.Actor{Username: username, Role: c.GetString("role")}
+
+	err := handler.uc.DeleteCommentOnAPosst(c, actor, blogOwner, postSlug, commentID)
 	if err != nil {
 		helpers.ResponseBuilder(c, err.Code, "delete comment", err.String(), nil)
 		return
@@ -177,7 +246,9 @@ func (handler *commentHandlerImpl) EditMyCommentOnAPost(c *gin.Context) {
 		return
 	}
 
-	ucErr := handler.uc.UpdateCommentOnAPost(c, username, blogOwner, postSlug, commentID, data)
+	actor := policy.Actor{Username: username, Role: c.GetString("role")}
+
+	ucErr := handler.uc.UpdateCommentOnAPost(c, actor, blogOwner, postSlug, commentID, data)
 	if ucErr != nil {
 		helpers.ResponseBuilder(c, ucErr.Code, "edit comment", ucErr.String(), nil)
 		return
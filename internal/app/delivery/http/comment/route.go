@@ -6,6 +6,7 @@ import (
 	commentrepository "goproject/internal/app/repository/comment"
 	postrepository "goproject/internal/app/repository/post"
 	commentusecase "goproject/internal/app/usecase/comment"
+	"goproject/internal/domain/policy"
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
@@ -18,12 +19,15 @@ func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
 	usecase := commentusecase.NewCommentUsecase(commentRepository, postRepository, logger)
 	handler := commenthandler.NewCommentHandler(usecase)
 
-	r.GET("/my/comments", middlewares.JWTAuthMiddleware(), handler.GetMyComments)
+	canWrite := middlewares.JWTAuthMiddleware(db, policy.ScopeCommentsWrite)
+
+	r.GET("/my/comments", middlewares.JWTAuthMiddleware(db, policy.ScopeCommentsRead), handler.GetMyComments)
 	comment := r.Group("/blog/:username/posts/:post_slug/comments")
 	{
-		comment.POST("", middlewares.JWTAuthMiddleware(), handler.CreateComment)
-		comment.GET("", handler.GetCommentsOnAPost)
-		comment.DELETE("/:comment_id", middlewares.JWTAuthMiddleware(), handler.DeleteCommentByID)
-		comment.PUT("/:comment_id", middlewares.JWTAuthMiddleware(), handler.EditMyCommentOnAPost)
+		comment.POST("", canWrite, middlewares.RequireVerifiedEmail(db), handler.CreateComment)
+		comment.GET("", middlewares.RedirectUsernameAlias(db), handler.GetCommentsOnAPost)
+		comment.DELETE("/:comment_id", canWrite, handler.DeleteCommentByID)
+		comment.PUT("/:comment_id", canWrite, middlewares.RequireVerifiedEmail(db), handler.EditMyCommentOnAPost)
+		comment.POST("/:comment_id/replies", canWrite, middlewares.RequireVerifiedEmail(db), handler.ReplyToComment)
 	}
 }
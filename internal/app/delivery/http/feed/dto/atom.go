@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	XMLNS    string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []AtomLink  `xml:"link"`
+	Author   *AtomPerson `xml:"author,omitempty"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type AtomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Link       AtomLink       `xml:"link"`
+	Author     *AtomPerson    `xml:"author,omitempty"`
+	Categories []AtomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    AtomContent    `xml:"content"`
+}
+
+func NewAtomFeed(feed Feed, apiURL, selfURL string) AtomFeed {
+	atom := AtomFeed{
+		XMLNS:    "http://www.w3.org/2005/Atom",
+		ID:       selfURL,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  feed.Updated.UTC().Format(time.RFC3339),
+		Links: []AtomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: selfURL},
+		},
+		Entries: make([]AtomEntry, 0, len(feed.Items)),
+	}
+
+	if feed.Path != "" {
+		atom.Links = append(atom.Links, AtomLink{Rel: "alternate", Type: "application/json", Href: apiURL + feed.Path})
+	}
+	if feed.Author != "" {
+		atom.Author = &AtomPerson{Name: feed.Author}
+	}
+
+	for _, item := range feed.Items {
+		entry := AtomEntry{
+			ID:        apiURL + item.Path,
+			Title:     item.Title,
+			Published: item.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   item.UpdatedAt.UTC().Format(time.RFC3339),
+			Link:      AtomLink{Rel: "alternate", Href: apiURL + item.Path},
+			Author:    &AtomPerson{Name: item.Author},
+			Summary:   item.Summary,
+			Content:   AtomContent{Type: "html", Body: item.ContentHTML},
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, AtomCategory{Term: tag})
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return atom
+}
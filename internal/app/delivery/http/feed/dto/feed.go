@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// Feed is a format-agnostic feed, it's turned into Atom, RSS or JSON Feed by the handler.
+// Paths are relative to the API root, e.g. blog/johndoe/posts.
+type Feed struct {
+	Title       string
+	Description string
+	Path        string // collection the feed mirrors, empty when there's no such endpoint
+	Author      string
+	Updated     time.Time
+	Items       []FeedItem
+}
+
+type FeedItem struct {
+	Title       string
+	Summary     string
+	ContentHTML string
+	Path        string
+	Author      string
+	Tags        []string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// JSONFeed follows https://www.jsonfeed.org/version/1.1/
+type JSONFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description,omitempty"`
+	Authors     []JSONFeedAuthor `json:"authors,omitempty"`
+	Items       []JSONFeedItem   `json:"items"`
+}
+
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	Summary       string           `json:"summary,omitempty"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []JSONFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+func NewJSONFeed(feed Feed, apiURL, selfURL string) JSONFeed {
+	jsonFeed := JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		FeedURL:     selfURL,
+		Description: feed.Description,
+		Items:       make([]JSONFeedItem, 0, len(feed.Items)),
+	}
+
+	if feed.Path != "" {
+		jsonFeed.HomePageURL = apiURL + feed.Path
+	}
+	if feed.Author != "" {
+		jsonFeed.Authors = []JSONFeedAuthor{{Name: feed.Author}}
+	}
+
+	for _, item := range feed.Items {
+		jsonFeed.Items = append(jsonFeed.Items, JSONFeedItem{
+			ID:            apiURL + item.Path,
+			URL:           apiURL + item.Path,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			Summary:       item.Summary,
+			DatePublished: item.PublishedAt.UTC().Format(time.RFC3339),
+			DateModified:  item.UpdatedAt.UTC().Format(time.RFC3339),
+			Authors:       []JSONFeedAuthor{{Name: item.Author}},
+			Tags:          item.Tags,
+		})
+	}
+
+	return jsonFeed
+}
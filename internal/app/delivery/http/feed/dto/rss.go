@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type RSSFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XMLNSAtom string     `xml:"xmlns:atom,attr"`
+	XMLNSDC   string     `xml:"xmlns:dc,attr"`
+	Channel   RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      AtomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []RSSItem `xml:"item"`
+}
+
+type RSSGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type RSSItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        RSSGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+func NewRSSFeed(feed Feed, apiURL, selfURL string) RSSFeed {
+	link := selfURL
+	if feed.Path != "" {
+		link = apiURL + feed.Path
+	}
+
+	rss := RSSFeed{
+		Version:   "2.0",
+		XMLNSAtom: "http://www.w3.org/2005/Atom",
+		XMLNSDC:   "http://purl.org/dc/elements/1.1/",
+		Channel: RSSChannel{
+			Title:         feed.Title,
+			Link:          link,
+			Description:   feed.Description,
+			AtomLink:      AtomLink{Rel: "self", Type: "application/rss+xml", Href: selfURL},
+			LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+			Items:         make([]RSSItem, 0, len(feed.Items)),
+		},
+	}
+
+	// description is required on a channel
+	if rss.Channel.Description == "" {
+		rss.Channel.Description = feed.Title
+	}
+
+	for _, item := range feed.Items {
+		rss.Channel.Items = append(rss.Channel.Items, RSSItem{
+			Title:       item.Title,
+			Link:        apiURL + item.Path,
+			GUID:        RSSGUID{IsPermaLink: "true", Value: apiURL + item.Path},
+			PubDate:     item.PublishedAt.UTC().Format(time.RFC1123Z),
+			Creator:     item.Author,
+			Categories:  item.Tags,
+			Description: item.ContentHTML,
+		})
+	}
+
+	return rss
+}
@@ -0,0 +1,174 @@
+package feedhandler
+
+import (
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"goproject/internal/app/delivery/http/feed/dto"
+	feedusecase "goproject/internal/app/usecase/feed"
+	"goproject/internal/helpers"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler interface {
+	GetBlogFeed(c *gin.Context)
+	GetTagFeed(c *gin.Context)
+	GetListFeed(c *gin.Context)
+}
+
+type feedHandlerImpl struct {
+	uc feedusecase.FeedUsecase
+}
+
+func NewFeedHandler(uc feedusecase.FeedUsecase) FeedHandler {
+	return &feedHandlerImpl{
+		uc: uc,
+	}
+}
+
+//	@GetBlogFeed	godoc
+//	@Summary		Get a blog's feed
+//	@Description	Get the newest published posts of a user's blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.
+//	@Description	Supports conditional requests with If-None-Match and If-Modified-Since.
+//	@Tags			Feed
+//	@Param			username	path	string	true	"user's username"
+//	@Param			tag			query	string	false	"only include posts with this tag"
+//	@Produce		application/atom+xml,application/rss+xml,application/feed+json
+//	@Success		200	{object}	dto.JSONFeed
+//	@Success		304
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/blog/{username}/feed.atom [get]
+//	@Router			/blog/{username}/feed.rss [get]
+//	@Router			/blog/{username}/feed.json [get]
+func (handler *feedHandlerImpl) GetBlogFeed(c *gin.Context) {
+	feed, ucErr := handler.uc.GetBlogFeed(c, c.Param("username"), c.Query("tag"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get blog feed", ucErr.String(), nil)
+		return
+	}
+
+	render(c, *feed)
+}
+
+//	@GetTagFeed		godoc
+//	@Summary		Get a tag's feed
+//	@Description	Get the newest published posts with a tag from every blog as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.
+//	@Description	Supports conditional requests with If-None-Match and If-Modified-Since.
+//	@Tags			Feed
+//	@Param			tag	path	string	true	"slug of the tag"
+//	@Produce		application/atom+xml,application/rss+xml,application/feed+json
+//	@Success		200	{object}	dto.JSONFeed
+//	@Success		304
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/tags/{tag}/feed.atom [get]
+//	@Router			/tags/{tag}/feed.rss [get]
+//	@Router			/tags/{tag}/feed.json [get]
+func (handler *feedHandlerImpl) GetTagFeed(c *gin.Context) {
+	feed, ucErr := handler.uc.GetTagFeed(c, c.Param("tag"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get tag feed", ucErr.String(), nil)
+		return
+	}
+
+	render(c, *feed)
+}
+
+//	@GetListFeed	godoc
+//	@Summary		Get a list's feed
+//	@Description	Get the posts in a public list as an Atom, RSS 2.0 or JSON Feed 1.1 feed, depending on the extension.
+//	@Description	Private lists don't have a feed.
+//	@Description	Supports conditional requests with If-None-Match and If-Modified-Since.
+//	@Tags			Feed
+//	@Param			username	path	string	true	"list owner's username"
+//	@Param			list_slug	path	string	true	"slug of the list"
+//	@Produce		application/atom+xml,application/rss+xml,application/feed+json
+//	@Success		200	{object}	dto.JSONFeed
+//	@Success		304
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/lists/{username}/{list_slug}/feed.atom [get]
+//	@Router			/lists/{username}/{list_slug}/feed.rss [get]
+//	@Router			/lists/{username}/{list_slug}/feed.json [get]
+func (handler *feedHandlerImpl) GetListFeed(c *gin.Context) {
+	feed, ucErr := handler.uc.GetListFeed(c, c.Param("username"), c.Param("list_slug"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get list feed", ucErr.String(), nil)
+		return
+	}
+
+	render(c, *feed)
+}
+
+// render writes the feed in the format picked by the route's extension, or 304 when the client's copy is still fresh.
+func render(c *gin.Context, feed dto.Feed) {
+	format := path.Ext(c.FullPath())
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+	etag := feedETag(c.Request.URL.RequestURI(), format, feed)
+
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	if notModified(c, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	apiURL := helpers.APIURL("/")
+	selfURL := helpers.AppURL() + c.Request.URL.RequestURI()
+
+	switch format {
+	case ".atom":
+		renderXML(c, "application/atom+xml; charset=utf-8", dto.NewAtomFeed(feed, apiURL, selfURL))
+	case ".rss":
+		renderXML(c, "application/rss+xml; charset=utf-8", dto.NewRSSFeed(feed, apiURL, selfURL))
+	default:
+		c.Header("Content-Type", "application/feed+json; charset=utf-8")
+		c.JSON(http.StatusOK, dto.NewJSONFeed(feed, apiURL, selfURL))
+	}
+}
+
+// feedETag changes whenever an item is added, removed or edited, a post dropping out of a full feed leaves both the
+// number of items and the newest update as they were.
+func feedETag(uri, format string, feed dto.Feed) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%d", uri, format, feed.Updated.UnixNano())
+	for _, item := range feed.Items {
+		fmt.Fprintf(h, "|%s|%d", item.Path, item.UpdatedAt.UnixNano())
+	}
+	return fmt.Sprintf(`"%x"`, h.Sum(nil))
+}
+
+// notModified checks the request's conditional headers, If-None-Match wins over If-Modified-Since like RFC 9110 says.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := c.GetHeader("If-Modified-Since"); ims != "" {
+		t, err := http.ParseTime(ims)
+		return err == nil && !lastModified.After(t)
+	}
+
+	return false
+}
+
+func renderXML(c *gin.Context, contentType string, feed any) {
+	b, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		c.Error(err)
+		helpers.ResponseBuilder(c, http.StatusInternalServerError, "get feed", "it's our fault, not yours", nil)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), b...))
+}
@@ -0,0 +1,33 @@
+package feed
+
+import (
+	feedhandler "goproject/internal/app/delivery/http/feed/handler"
+	"goproject/internal/app/delivery/http/middlewares"
+	blogrepository "goproject/internal/app/repository/blog"
+	listrepository "goproject/internal/app/repository/list"
+	postrepository "goproject/internal/app/repository/post"
+	tagrepository "goproject/internal/app/repository/tag"
+	feedusecase "goproject/internal/app/usecase/feed"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	blogRepository := blogrepository.NewBlogRepository(db)
+	postRepository := postrepository.NewPostRepository(db)
+	tagRepository := tagrepository.NewTagRepository(db)
+	listRepository := listrepository.NewListRepository(db)
+	usecase := feedusecase.NewFeedUsecase(blogRepository, postRepository, tagRepository, listRepository, logger)
+	handler := feedhandler.NewFeedHandler(usecase)
+
+	redirectAlias := middlewares.RedirectUsernameAlias(db)
+
+	// the format is picked from the extension of the route
+	for _, format := range []string{"atom", "rss", "json"} {
+		r.GET("/blog/:username/feed."+format, redirectAlias, handler.GetBlogFeed)
+		r.GET("/tags/:tag/feed."+format, handler.GetTagFeed)
+		r.GET("/lists/:username/:list_slug/feed."+format, redirectAlias, handler.GetListFeed)
+	}
+}
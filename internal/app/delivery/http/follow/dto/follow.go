@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type FollowResponse struct {
+	Username   string    `json:"username"`
+	Name       string    `json:"name"`
+	FollowedAt time.Time `json:"followed_at"`
+}
@@ -0,0 +1,149 @@
+package followhandler
+
+import (
+	followusecase "goproject/internal/app/usecase/follow"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FollowHandler interface {
+	Follow(c *gin.Context)
+	Unfollow(c *gin.Context)
+	GetFollowers(c *gin.Context)
+	GetFollowing(c *gin.Context)
+}
+
+type followHandlerImpl struct {
+	uc followusecase.FollowUsecase
+}
+
+func NewFollowHandler(uc followusecase.FollowUsecase) FollowHandler {
+	return &followHandlerImpl{
+		uc: uc,
+	}
+}
+
+//	@Follow			godoc
+//	@Summary		Follow a user
+//	@Description	Follow a user, their published posts will show up in current user's feed.
+//	@Tags			Follow
+//	@Param			username	path	string	true	"username of the user to follow"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/users/{username}/follow [post]
+func (handler *followHandlerImpl) Follow(c *gin.Context) {
+	followee := c.Param("username")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "follow user", "you're not allowed to access this path", nil)
+		return
+	}
+
+	ucErr := handler.uc.Follow(c, username, followee)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "follow user", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "follow user", nil, nil)
+}
+
+//	@Unfollow		godoc
+//	@Summary		Unfollow a user
+//	@Description	Stop following a user.
+//	@Tags			Follow
+//	@Param			username	path	string	true	"username of the user to unfollow"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/users/{username}/follow [delete]
+func (handler *followHandlerImpl) Unfollow(c *gin.Context) {
+	followee := c.Param("username")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "unfollow user", "you're not allowed to access this path", nil)
+		return
+	}
+
+	ucErr := handler.uc.Unfollow(c, username, followee)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "unfollow user", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "unfollow user", nil, nil)
+}
+
+//	@GetFollowers	godoc
+//	@Summary		Get a user's followers
+//	@Description	Get the users following a user, most recent first.
+//	@Description	Only the user can see them when they keep their follows private, the token is optional otherwise.
+//	@Security		BearerToken
+//	@Tags			Follow
+//	@Param			username	path	string	true	"user's username"
+//	@Param			limit		query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after		query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before		query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.FollowResponse}
+//	@Failure		403	{object}	helpers.ResponseWithError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/users/{username}/followers [get]
+func (handler *followHandlerImpl) GetFollowers(c *gin.Context) {
+	username := c.Param("username")
+
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get followers", err.Error(), nil)
+		return
+	}
+
+	followers, pagination, ucErr := handler.uc.GetFollowers(c, username, c.GetString("username"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get followers", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get followers", followers, pagination)
+}
+
+//	@GetFollowing	godoc
+//	@Summary		Get the users a user follows
+//	@Description	Get the users followed by a user, most recent first.
+//	@Description	Only the user can see them when they keep their follows private, the token is optional otherwise.
+//	@Security		BearerToken
+//	@Tags			Follow
+//	@Param			username	path	string	true	"user's username"
+//	@Param			limit		query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after		query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before		query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.FollowResponse}
+//	@Failure		403	{object}	helpers.ResponseWithError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/users/{username}/following [get]
+func (handler *followHandlerImpl) GetFollowing(c *gin.Context) {
+	username := c.Param("username")
+
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get following", err.Error(), nil)
+		return
+	}
+
+	following, pagination, ucErr := handler.uc.GetFollowing(c, username, c.GetString("username"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get following", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get following", following, pagination)
+}
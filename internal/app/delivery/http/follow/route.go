@@ -0,0 +1,33 @@
+package follow
+
+import (
+	followhandler "goproject/internal/app/delivery/http/follow/handler"
+	"goproject/internal/app/delivery/http/middlewares"
+	followrepository "goproject/internal/app/repository/follow"
+	userrepository "goproject/internal/app/repository/user"
+	followusecase "goproject/internal/app/usecase/follow"
+	"goproject/internal/domain/policy"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	followRepository := followrepository.NewFollowRepository(db)
+	userRepository := userrepository.NewUserRepository(db)
+	usecase := followusecase.NewFollowUsecase(followRepository, userRepository, logger)
+	handler := followhandler.NewFollowHandler(usecase)
+
+	redirectAlias := middlewares.RedirectUsernameAlias(db)
+	// users can always see their own follows, even when they keep them private
+	viewer := middlewares.OptionalJWTAuthMiddleware(db, policy.ScopeProfileRead)
+
+	follow := r.Group("/users/:username")
+	{
+		follow.POST("/follow", middlewares.JWTAuthMiddleware(db, policy.ScopeFollowsWrite), handler.Follow)
+		follow.DELETE("/follow", middlewares.JWTAuthMiddleware(db, policy.ScopeFollowsWrite), handler.Unfollow)
+		follow.GET("/followers", redirectAlias, viewer, handler.GetFollowers)
+		follow.GET("/following", redirectAlias, viewer, handler.GetFollowing)
+	}
+}
@@ -5,6 +5,8 @@ import "goproject/internal/app/delivery/http/post/dto"
 type ListRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	// IsPublic is left as it is on update when it's missing, a new list is private without it
+	IsPublic *bool `json:"is_public"`
 }
 
 type CreateListResponse struct {
@@ -15,6 +17,7 @@ type ListResponse struct {
 	Slug        string              `json:"list_slug"`
 	Name        string              `json:"name"`
 	Description string              `json:"description"`
+	IsPublic    bool                `json:"is_public"`
 	Posts       *[]dto.PostResponse `json:"posts,omitempty"` // use pointer so when it's empty, it'll still be rendered
 	NumOfPosts  *int                `json:"num_of_posts,omitempty"`
 }
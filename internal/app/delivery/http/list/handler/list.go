@@ -101,9 +101,12 @@ func (handler *listHandlerImpl) AddPostToMyList(c *gin.Context) {
 //	@Description		Get posts in my current user's by providing the list's slug.
 //	@Tags				List
 //	@Param				list_slug	path	string	true	"slug of the list you want to get the post from"
+//	@Param				limit		query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param				after		query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param				before		query	string	false	"cursor of the previous page, taken from pagination.prev"
 //	@Security			BearerToken
 //	@Produce			json
-//	@Success			200	{object}	helpers.ResponseWithData{data=helpers.PostsInMyListResponse}
+//	@Success			200	{object}	helpers.ResponseWithPagination{data=helpers.PostsInMyListResponse}
 //	@Router				/lists/my/{list_slug} [get]
 func (handler *listHandlerImpl) GetPostsInMyListBySlug(c *gin.Context) {
 	username := c.GetString("username")
@@ -114,13 +117,19 @@ func (handler *listHandlerImpl) GetPostsInMyListBySlug(c *gin.Context) {
 		return
 	}
 
-	list, err := handler.uc.GetPostsInAListBySlug(c, listSlug, username)
+	page, err := helpers.ParsePublishedPageRequest(c)
 	if err != nil {
-		helpers.ResponseBuilder(c, err.Code, "get posts in list", err.String(), nil)
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get posts in list", err.Error(), nil)
 		return
 	}
 
-	helpers.ResponseBuilder(c, http.StatusOK, "get posts in list", nil, list)
+	list, pagination, ucErr := handler.uc.GetPostsInAListBySlug(c, listSlug, username, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get posts in list", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get posts in list", list, pagination)
 }
 
 //	@GetMyLists		godoc
@@ -128,9 +137,12 @@ func (handler *listHandlerImpl) GetPostsInMyListBySlug(c *gin.Context) {
 //	@Description	Get all of current user's lists
 //	@Description	Will return an empty array ([]) if the user has no lists.
 //	@Tags			List
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
 //	@Security		BearerToken
 //	@Produce		json
-//	@Success		200	{object}	helpers.ResponseWithData{data=[]helpers.MyListResponse}
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]helpers.MyListResponse}
 //	@Router			/lists/my [get]
 func (handler *listHandlerImpl) GetMyLists(c *gin.Context) {
 	username := c.GetString("username")
@@ -140,13 +152,19 @@ func (handler *listHandlerImpl) GetMyLists(c *gin.Context) {
 		return
 	}
 
-	lists, err := handler.uc.GetMyLists(c, username)
+	page, err := helpers.ParsePageRequest(c)
 	if err != nil {
-		helpers.ResponseBuilder(c, err.Code, "get lists", err.String(), nil)
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get lists", err.Error(), nil)
+		return
+	}
+
+	lists, pagination, ucErr := handler.uc.GetMyLists(c, username, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get lists", ucErr.String(), nil)
 		return
 	}
 
-	helpers.ResponseBuilder(c, http.StatusOK, "get lists", nil, lists)
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get lists", lists, pagination)
 }
 
 //	@UpdateMyListInformation	godoc
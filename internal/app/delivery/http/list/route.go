@@ -6,6 +6,7 @@ import (
 	listrepository "goproject/internal/app/repository/list"
 	postrepository "goproject/internal/app/repository/post"
 	listusecase "goproject/internal/app/usecase/list"
+	"goproject/internal/domain/policy"
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
@@ -18,14 +19,17 @@ func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
 	usecase := listusecase.NewListUsecase(listRepository, postRepository, logger)
 	handler := listhandler.NewListHandler(usecase)
 
-	r.POST("/blog/:username/posts/:post_slug/save/:list_slug", middlewares.JWTAuthMiddleware(), handler.AddPostToMyList)
-	list := r.Group("/lists/my", middlewares.JWTAuthMiddleware())
+	canRead := middlewares.JWTAuthMiddleware(db, policy.ScopeListsRead)
+	canWrite := middlewares.JWTAuthMiddleware(db, policy.ScopeListsWrite)
+
+	r.POST("/blog/:username/posts/:post_slug/save/:list_slug", canWrite, handler.AddPostToMyList)
+	list := r.Group("/lists/my")
 	{
-		list.POST("", handler.CreateNewList)
-		list.GET("", handler.GetMyLists)
-		list.GET("/:list_slug", handler.GetPostsInMyListBySlug)
-		list.PUT("/:list_slug", handler.UpdateMyListInformationBySlug)
-		list.DELETE("/:list_slug", handler.DeleteMyListBySlug)
-		list.DELETE("/:list_slug/:post_slug", handler.RemovePostFromMyList)
+		list.POST("", canWrite, handler.CreateNewList)
+		list.GET("", canRead, handler.GetMyLists)
+		list.GET("/:list_slug", canRead, handler.GetPostsInMyListBySlug)
+		list.PUT("/:list_slug", canWrite, handler.UpdateMyListInformationBySlug)
+		list.DELETE("/:list_slug", canWrite, handler.DeleteMyListBySlug)
+		list.DELETE("/:list_slug/:post_slug", canWrite, handler.RemovePostFromMyList)
 	}
 }
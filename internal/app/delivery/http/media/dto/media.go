@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"io"
+	"mime/multipart"
+	"time"
+)
+
+type UploadMediaRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+// MediaResponse only has URLs once the image is processed, i.e. its status is ready.
+type MediaResponse struct {
+	ID              uint                   `json:"media_id"`
+	Filename        string                 `json:"filename"`
+	Status          string                 `json:"status"`                     // pending, processing, ready or failed
+	ProcessingError string                 `json:"processing_error,omitempty"` // why processing failed
+	Size            int64                  `json:"size"`
+	Width           int                    `json:"width"` // of the largest variant
+	Height          int                    `json:"height"`
+	URL             string                 `json:"url"`      // the largest variant
+	Srcset          string                 `json:"srcset"`   // every variant, for an <img> srcset attribute
+	Markdown        string                 `json:"markdown"` // ready to be pasted into a post
+	Variants        []MediaVariantResponse `json:"variants"`
+	CreatedAt       time.Time              `json:"created_at"`
+}
+
+type MediaVariantResponse struct {
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
+
+// MediaFile is a processed image being served, Body has to be closed.
+type MediaFile struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Body        io.ReadCloser
+}
@@ -0,0 +1,180 @@
+package mediahandler
+
+import (
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/media/dto"
+	mediausecase "goproject/internal/app/usecase/media"
+	"goproject/internal/helpers"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler interface {
+	UploadMedia(c *gin.Context)
+	GetMyMedia(c *gin.Context)
+	DeleteMedia(c *gin.Context)
+	ServeMedia(c *gin.Context)
+}
+
+// room for the multipart boundaries and headers around the file
+const multipartOverhead = 1 << 20
+
+type mediaHandlerImpl struct {
+	uc      mediausecase.MediaUsecase
+	maxSize int64
+}
+
+func NewMediaHandler(uc mediausecase.MediaUsecase, maxSize int64) MediaHandler {
+	return &mediaHandlerImpl{
+		uc:      uc,
+		maxSize: maxSize,
+	}
+}
+
+func filesURL() string {
+	return helpers.APIURL("/media/files")
+}
+
+// UploadMedia godoc
+//
+//	@Summary		Upload a media file
+//	@Description	Upload an image to use in posts, PNG, JPEG, GIF and WebP are allowed. The type is detected from the file's content.
+//	@Description	Files count towards a per-user quota. The image is resized and stripped of its metadata in the background,
+//	@Description	once its status is ready, use its url, srcset or markdown in a post's content to show it.
+//	@Tags			Media
+//	@Accept			multipart/form-data
+//	@Param			file	formData	file	true	"the file to upload"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		201	{object}	helpers.ResponseWithData{data=dto.MediaResponse}
+//	@Failure		400	{object}	helpers.ResponseWithError
+//	@Failure		413	{object}	helpers.ResponseWithError
+//	@Failure		415	{object}	helpers.ResponseWithError
+//	@Router			/media [post]
+func (handler *mediaHandlerImpl) UploadMedia(c *gin.Context) {
+	var data dto.UploadMediaRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "upload media", "you're not allowed to access this path", nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, handler.maxSize+multipartOverhead)
+
+	err := c.ShouldBind(&data)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			helpers.ResponseBuilder(c, http.StatusRequestEntityTooLarge, "upload media", fmt.Sprintf("file must not be larger than %d MB", handler.maxSize>>20), nil)
+			return
+		}
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "upload media", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.UploadMedia(c, username, data, filesURL())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "upload media", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusCreated, "upload media", nil, resp)
+}
+
+// GetMyMedia godoc
+//
+//	@Summary		Get current user's media
+//	@Description	Get the files current user uploaded, newest first, along with their processing status.
+//	@Tags			Media
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.MediaResponse}
+//	@Router			/media [get]
+func (handler *mediaHandlerImpl) GetMyMedia(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "get media", "you're not allowed to access this path", nil)
+		return
+	}
+
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get media", err.Error(), nil)
+		return
+	}
+
+	media, pagination, ucErr := handler.uc.GetMyMedia(c, username, page, filesURL())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get media", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get media", media, pagination)
+}
+
+// DeleteMedia godoc
+//
+//	@Summary		Delete a media file
+//	@Description	Delete one of current user's files, posts that still use it won't be able to show it anymore.
+//	@Tags			Media
+//	@Param			media_id	path	int	true	"media's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/media/{media_id} [delete]
+func (handler *mediaHandlerImpl) DeleteMedia(c *gin.Context) {
+	mediaID := c.Param("media_id")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "delete media", "you're not allowed to access this path", nil)
+		return
+	}
+
+	ucErr := handler.uc.DeleteMedia(c, username, mediaID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "delete media", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "delete media", nil, nil)
+}
+
+// ServeMedia godoc
+//
+//	@Summary		Download a media file
+//	@Description	Download a processed image, this is where media urls point to unless the storage serves files itself.
+//	@Tags			Media
+//	@Param			key	path	string	true	"media's key, e.g. johndoe/abc.png"
+//	@Produce		image/png,image/jpeg,image/gif,image/webp
+//	@Success		200
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/media/files/{key} [get]
+func (handler *mediaHandlerImpl) ServeMedia(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	file, ucErr := handler.uc.OpenMedia(c, key)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get media file", ucErr.String(), nil)
+		return
+	}
+	defer file.Body.Close()
+
+	// keys are never reused, so files can be cached for good
+	c.DataFromReader(http.StatusOK, file.Size, file.ContentType, file.Body, map[string]string{
+		"Cache-Control":           "public, max-age=31536000, immutable",
+		"Content-Disposition":     mime.FormatMediaType("inline", map[string]string{"filename": file.Filename}),
+		"Content-Security-Policy": "default-src 'none'",
+		"X-Content-Type-Options":  "nosniff",
+	})
+}
@@ -0,0 +1,26 @@
+package media
+
+import (
+	mediahandler "goproject/internal/app/delivery/http/media/handler"
+	"goproject/internal/app/delivery/http/middlewares"
+	mediausecase "goproject/internal/app/usecase/media"
+	"goproject/internal/domain/policy"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, usecase mediausecase.MediaUsecase, limits mediausecase.Limits) {
+	handler := mediahandler.NewMediaHandler(usecase, limits.MaxSize)
+
+	canRead := middlewares.JWTAuthMiddleware(db, policy.ScopeMediaRead)
+	canWrite := middlewares.JWTAuthMiddleware(db, policy.ScopeMediaWrite)
+
+	media := r.Group("/media")
+	{
+		media.POST("", canWrite, middlewares.RequireVerifiedEmail(db), handler.UploadMedia)
+		media.GET("", canRead, handler.GetMyMedia)
+		media.DELETE("/:media_id", canWrite, handler.DeleteMedia)
+		media.GET("/files/*key", handler.ServeMedia)
+	}
+}
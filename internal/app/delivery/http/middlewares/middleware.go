@@ -1,12 +1,23 @@
 package middlewares
 
 import (
+	"errors"
 	"fmt"
+	accesstokenrepository "goproject/internal/app/repository/accesstoken"
+	sessionrepository "goproject/internal/app/repository/session"
+	userrepository "goproject/internal/app/repository/user"
+	usernamealiasrepository "goproject/internal/app/repository/usernamealias"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/policy"
+	"goproject/internal/domain/repository"
 	"goproject/internal/utils"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func getToken(raw string) (string, bool) {
@@ -17,7 +28,22 @@ func getToken(raw string) (string, bool) {
 	return raw, false
 }
 
-func JWTAuthMiddleware() gin.HandlerFunc {
+// internalError is for when the database can't be asked, the client isn't to blame and shouldn't be logged out.
+func internalError(c *gin.Context) {
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"success": false,
+		"message": "it's our fault, not yours",
+	})
+	c.Abort()
+}
+
+// JWTAuthMiddleware authenticates the request with either a JWT from a login session or a personal access token.
+// Personal access tokens are only accepted when they have one of the given scopes, so a route without scopes is
+// only reachable with a login session.
+func JWTAuthMiddleware(db *gorm.DB, scopes ...policy.Scope) gin.HandlerFunc {
+	sessionRepository := sessionrepository.NewSessionRepository(db)
+	accessTokenRepository := accesstokenrepository.NewAccessTokenRepository(db)
+
 	return func(c *gin.Context) {
 		token, isValid := getToken(c.GetHeader("Authorization"))
 		if token == "" {
@@ -38,6 +64,11 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if strings.HasPrefix(token, utils.PersonalAccessTokenPrefix) {
+			authenticateAccessToken(c, accessTokenRepository, token, scopes)
+			return
+		}
+
 		claims, err := utils.DecodeJWT(token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -48,7 +79,200 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("username", claims["username"])
+		sid, ok := claims["sid"].(float64)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Token is not bound to a session",
+			})
+			c.Abort()
+			return
+		}
+
+		session, err := sessionRepository.FindByID(c, uint(sid))
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			internalError(c)
+			return
+		}
+		if err != nil || session.RevokedAt != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Session has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
+		// suspending a user revokes their sessions, this is in case one was made at the same time
+		if session.User.SuspendedAt != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "Account is suspended",
+			})
+			c.Abort()
+			return
+		}
+
+		// the role comes from the user rather than the token, so a role change counts from the next request
+		role := session.User.Role
+		if role == "" {
+			role = model.RoleUser
+		}
+
+		// the session follows the user through a rename, the token keeps the name it was issued with
+		c.Set("username", session.Username)
+		c.Set("role", role)
+		c.Set("session_id", session.ID)
+		c.Next()
+	}
+}
+
+// OptionalJWTAuthMiddleware is JWTAuthMiddleware for routes anyone can read, requests without an Authorization header
+// go through anonymously. Those with one have to be authenticated like on any other route.
+func OptionalJWTAuthMiddleware(db *gorm.DB, scopes ...policy.Scope) gin.HandlerFunc {
+	auth := JWTAuthMiddleware(db, scopes...)
+
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+
+		auth(c)
+	}
+}
+
+func authenticateAccessToken(c *gin.Context, repo repository.AccessTokenRepository, token string, scopes []policy.Scope) {
+	pat, err := repo.FindByHash(c, utils.HashToken(token))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		internalError(c)
+		return
+	}
+	if err != nil || pat.RevokedAt != nil || (pat.ExpiresAt != nil && pat.ExpiresAt.Before(time.Now())) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": "Access token is invalid or has expired",
+		})
+		c.Abort()
+		return
+	}
+
+	if pat.User.SuspendedAt != nil {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Account is suspended",
+		})
+		c.Abort()
+		return
+	}
+
+	granted := strings.Fields(pat.Scopes)
+	allowed := slices.ContainsFunc(scopes, func(scope policy.Scope) bool {
+		return slices.Contains(granted, string(scope))
+	})
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Access token doesn't have the scope required by this path",
+		})
+		c.Abort()
+		return
+	}
+
+	// a minute is precise enough, no need to write on every request
+	if pat.LastUsedAt == nil || time.Since(*pat.LastUsedAt) > time.Minute {
+		if err := repo.MarkUsed(c, pat.ID); err != nil {
+			c.Error(err)
+		}
+	}
+
+	role := pat.User.Role
+	if role == "" {
+		role = model.RoleUser
+	}
+
+	c.Set("username", pat.Username)
+	c.Set("role", role)
+	c.Set("access_token_id", pat.ID)
+	c.Next()
+}
+
+// RequirePermission only lets the request through when the role set by JWTAuthMiddleware is allowed to do the action.
+func RequirePermission(action policy.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.Can(c.GetString("role"), action) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "You don't have permission to access this path",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail only lets users who verified their email through, it has to come after JWTAuthMiddleware.
+func RequireVerifiedEmail(db *gorm.DB) gin.HandlerFunc {
+	userRepository := userrepository.NewUserRepository(db)
+
+	return func(c *gin.Context) {
+		user, err := userRepository.FindByUsername(c, c.GetString("username"))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "UNAUTHORIZED",
+			})
+			c.Abort()
+			return
+		}
+
+		if user.EmailVerifiedAt == nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "Please verify your email first",
+			})
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
+
+// RedirectUsernameAlias answers requests for a name a user renamed themselves from with a permanent redirect to the
+// same path under their current name. Only reads are redirected, anything else would have to be sent again anyway.
+func RedirectUsernameAlias(db *gorm.DB) gin.HandlerFunc {
+	aliasRepository := usernamealiasrepository.NewUsernameAliasRepository(db)
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		alias, err := aliasRepository.FindByOldUsername(c, c.Param("username"))
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		// the route tells which segment of the path is the username
+		route := strings.Split(c.FullPath(), "/")
+		segments := strings.Split(c.Request.URL.Path, "/")
+		index := slices.Index(route, ":username")
+		if index < 0 || len(segments) != len(route) {
+			c.Next()
+			return
+		}
+		segments[index] = alias.Username
+
+		location := *c.Request.URL
+		location.Path = strings.Join(segments, "/")
+		location.RawPath = ""
+
+		c.Redirect(http.StatusMovedPermanently, location.RequestURI())
+		c.Abort()
+	}
+}
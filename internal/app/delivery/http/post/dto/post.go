@@ -1,20 +1,81 @@
 package dto
 
-import "time"
+import (
+	"goproject/internal/domain/model"
+	"goproject/internal/utils"
+	"time"
+)
 
 type PostRequest struct {
-	Title   string `json:"title" binding:"required"`
-	Content string `json:"content" binding:"required"`
+	Title     string     `json:"title" binding:"required"`
+	Content   string     `json:"content" binding:"required"`
+	Status    string     `json:"status" binding:"omitempty,oneof=draft scheduled published archived"`
+	PublishAt *time.Time `json:"publish_at" binding:"required_if=Status scheduled"`
+	Tags      []string   `json:"tags" binding:"max=10,dive,max=50"`
 }
 
 type CreatePostResponse struct {
 	Slug string `json:"post_slug"`
 }
+
+type PreviewRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type PreviewResponse struct {
+	ContentHTML        string `json:"content_html"`
+	Excerpt            string `json:"excerpt"`
+	ReadingTimeMinutes int    `json:"reading_time_minutes"`
+}
+
 type PostResponse struct {
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	Slug      string    `json:"post_slug"`
-	Author    string    `json:"author"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Title              string     `json:"title"`
+	Content            string     `json:"content"` // markdown source
+	ContentHTML        string     `json:"content_html"`
+	Excerpt            string     `json:"excerpt"`
+	ReadingTimeMinutes int        `json:"reading_time_minutes"`
+	Slug               string     `json:"post_slug"`
+	Author             string     `json:"author"`
+	Status             string     `json:"status"`
+	Tags               []string   `json:"tags"`
+	PublishedAt        *time.Time `json:"published_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func NewPostResponse(post model.Post) PostResponse {
+	tags := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tags = append(tags, tag.Slug)
+	}
+
+	return PostResponse{
+		Title:              post.Title,
+		Content:            post.Content,
+		ContentHTML:        post.ContentHTML,
+		Excerpt:            post.Excerpt,
+		ReadingTimeMinutes: post.ReadingTimeMinutes,
+		Slug:               post.Slug,
+		Author:             post.Blog.User.Name,
+		Status:             post.Status,
+		Tags:               tags,
+		PublishedAt:        post.PublishedAt,
+		CreatedAt:          post.CreatedAt,
+		UpdatedAt:          post.UpdatedAt,
+	}
+}
+
+type RevisionResponse struct {
+	Revision     uint      `json:"revision"`
+	Title        string    `json:"title"`
+	Content      string    `json:"content,omitempty"`
+	RestoredFrom *uint     `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type RevisionDiffResponse struct {
+	From    uint             `json:"from"`
+	To      uint             `json:"to"`
+	Title   []utils.DiffLine `json:"title"`
+	Content []utils.DiffLine `json:"content"`
 }
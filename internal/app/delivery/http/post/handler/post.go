@@ -14,9 +14,16 @@ type PostHandler interface {
 	CreateNewPost(c *gin.Context)
 	GetPostsByBlogOwner(c *gin.Context)
 	GetAllMyBlogPosts(c *gin.Context)
+	GetFeed(c *gin.Context)
 	GetPostBySlug(c *gin.Context)
+	GetMyPostBySlug(c *gin.Context)
 	UpdateMyPostBySlug(c *gin.Context)
 	DeleteMyPostBySlug(c *gin.Context)
+	GetMyPostRevisions(c *gin.Context)
+	GetMyPostRevision(c *gin.Context)
+	DiffMyPostRevisions(c *gin.Context)
+	RestoreMyPostRevision(c *gin.Context)
+	PreviewPost(c *gin.Context)
 }
 
 type postHandlerImpl struct {
@@ -32,12 +39,16 @@ func NewPostHandler(uc postusecase.PostUsecase) PostHandler {
 //	@CreateNewPost	godoc
 //	@Summary		Create a new blog post
 //	@Description	Create a new post on current user's blog.
+//	@Description	A post can be a draft, scheduled (publish_at is required) or published. When status is omitted, the post is published right away.
+//	@Description	Content is Markdown (CommonMark with GitHub's tables, task lists, strikethrough and autolinks, plus footnotes), posts are returned with it rendered to sanitized HTML.
 //	@Description	Upon successful creation, it will return the newly created post's slug
+//	@Description	Only users with a verified email can create posts.
 //	@Tags			Post
 //	@Param			Body	body	dto.PostRequest	true	"data required to create a new post"
 //	@Security		BearerToken
 //	@Produce		json
 //	@Success		201	{object}	helpers.ResponseWithData{data=dto.CreatePostResponse}
+//	@Failure		403	{object}	helpers.ResponseWithError
 //	@Router			/blog/my/posts [post]
 func (handler *postHandlerImpl) CreateNewPost(c *gin.Context) {
 	var data dto.PostRequest
@@ -65,11 +76,15 @@ func (handler *postHandlerImpl) CreateNewPost(c *gin.Context) {
 
 //	@GetMyPosts		godoc
 //	@Summary		Get all current user's blog posts
-//	@Description	Get all current user's blog posts. When there are no posts, it will return an empty array ([]).
+//	@Description	Get all current user's blog posts, including drafts, scheduled and archived posts. When there are no posts, it will return an empty array ([]).
 //	@Tags			Post
+//	@Param			tag		query	string	false	"only return posts with this tag"
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
 //	@Security		BearerToken
 //	@Produce		json
-//	@Success		200	{object}	helpers.ResponseWithData{data=[]dto.PostResponse}
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.PostResponse}
 //	@Router			/blog/my/posts [GET]
 func (handler *postHandlerImpl) GetAllMyBlogPosts(c *gin.Context) {
 	username := c.GetString("username")
@@ -79,39 +94,88 @@ func (handler *postHandlerImpl) GetAllMyBlogPosts(c *gin.Context) {
 		return
 	}
 
-	posts, err := handler.uc.GetPostsByBlogOwner(c, username)
+	page, err := helpers.ParsePageRequest(c)
 	if err != nil {
-		helpers.ResponseBuilder(c, err.Code, "get my posts", err.String(), nil)
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get my posts", err.Error(), nil)
 		return
 	}
 
-	helpers.ResponseBuilder(c, http.StatusOK, "get my posts", nil, posts)
+	posts, pagination, ucErr := handler.uc.GetMyPosts(c, username, c.Query("tag"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get my posts", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get my posts", posts, pagination)
+}
+
+//	@GetFeed		godoc
+//	@Summary		Get current user's feed
+//	@Description	Get published posts from the blogs of the users current user follows, newest first.
+//	@Tags			Post
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.PostResponse}
+//	@Router			/feed [get]
+func (handler *postHandlerImpl) GetFeed(c *gin.Context) {
+	username := c.GetString("username")
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "get feed", "you're not allowed to access this path", nil)
+		return
+	}
+
+	page, err := helpers.ParsePublishedPageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get feed", err.Error(), nil)
+		return
+	}
+
+	posts, pagination, ucErr := handler.uc.GetFeed(c, username, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get feed", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get feed", posts, pagination)
 }
 
 //	@GetUsersBlogPosts	godoc
 //	@Summary			Get a user's blog posts
-//	@Description		Get user's blog posts by providing their username.
+//	@Description		Get user's published blog posts by providing their username.
 //	@Tags				Post
 //	@Param				username	path	string	true	"user's username"
+//	@Param				tag			query	string	false	"only return posts with this tag"
+//	@Param				limit		query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param				after		query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param				before		query	string	false	"cursor of the previous page, taken from pagination.prev"
 //	@Produce			json
-//	@Success			200	{object}	helpers.ResponseWithData{data=[]dto.PostResponse}
+//	@Success			200	{object}	helpers.ResponseWithPagination{data=[]dto.PostResponse}
 //	@Failure			404	{object}	helpers.ResponseWithError
 //	@Router				/blog/{username}/posts [GET]
 func (handler *postHandlerImpl) GetPostsByBlogOwner(c *gin.Context) {
 	username := c.Param("username")
 
-	posts, err := handler.uc.GetPostsByBlogOwner(c, username)
+	page, err := helpers.ParsePublishedPageRequest(c)
 	if err != nil {
-		helpers.ResponseBuilder(c, err.Code, fmt.Sprintf("get %s's posts", username), err.String(), nil)
+		helpers.ResponseBuilder(c, http.StatusBadRequest, fmt.Sprintf("get %s's posts", username), err.Error(), nil)
+		return
+	}
+
+	posts, pagination, ucErr := handler.uc.GetPostsByBlogOwner(c, username, c.Query("tag"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, fmt.Sprintf("get %s's posts", username), ucErr.String(), nil)
 		return
 	}
 
-	helpers.ResponseBuilder(c, http.StatusOK, fmt.Sprintf("get %s's posts", username), nil, posts)
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, fmt.Sprintf("get %s's posts", username), posts, pagination)
 }
 
 //	@GetUserPostBySlug	godoc
 //	@Summary			Get a specific post
-//	@Description		Get a specific post by providing their username and the post's slug.
+//	@Description		Get a specific published post by providing their username and the post's slug.
 //	@Tags				Post
 //	@Param				username	path	string	true	"user's username"
 //	@Param				post_slug	path	string	true	"post's slug"
@@ -131,9 +195,39 @@ func (handler *postHandlerImpl) GetPostBySlug(c *gin.Context) {
 	helpers.ResponseBuilder(c, http.StatusOK, "get post", nil, post)
 }
 
+//	@GetMyPostBySlug	godoc
+//	@Summary			Get current user's post
+//	@Description		Get current user's blog post by providing the post's slug, regardless of its status.
+//	@Tags				Post
+//	@Security			BearerToken
+//	@Param				post_slug	path	string	true	"post's slug"
+//	@Produce			json
+//	@Success			200	{object}	helpers.ResponseWithData{data=dto.PostResponse}
+//	@Failure			404	{object}	helpers.ResponseWithError
+//	@Router				/blog/my/posts/{post_slug} [GET]
+func (handler *postHandlerImpl) GetMyPostBySlug(c *gin.Context) {
+	slug := c.Param("post_slug")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "get my post", "you're not allowed to access this path", nil)
+		return
+	}
+
+	post, err := handler.uc.GetMyPostBySlug(c, username, slug)
+	if err != nil {
+		helpers.ResponseBuilder(c, err.Code, "get my post", err.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "get my post", nil, post)
+}
+
 //	@UpdateMyPostBySlug	godoc
 //	@Summary			Update/modify current user's post
 //	@Description		Update/modify current user's blog post by providing the post's slug.
+//	@Description		Every update is kept as a new revision of the post.
+//	@Description		When status is omitted, the post keeps its current status.
 //	@Tags				Post
 //	@Security			BearerToken
 //	@Param				Body		body	dto.PostRequest	true	"data required to update/modify a post"
@@ -195,3 +289,164 @@ func (handler *postHandlerImpl) DeleteMyPostBySlug(c *gin.Context) {
 
 	helpers.ResponseBuilder(c, http.StatusOK, "delete post", nil, nil)
 }
+
+//	@GetMyPostRevisions	godoc
+//	@Summary			Get revisions of current user's post
+//	@Description		Get every revision of current user's post, newest first. Content is omitted, use the single revision endpoint to get it.
+//	@Tags				Post
+//	@Param				limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param				after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param				before	query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Security			BearerToken
+//	@Param				post_slug	path	string	true	"post's slug"
+//	@Produce			json
+//	@Success			200	{object}	helpers.ResponseWithPagination{data=[]dto.RevisionResponse}
+//	@Failure			404	{object}	helpers.ResponseWithError
+//	@Router				/blog/my/posts/{post_slug}/revisions [GET]
+func (handler *postHandlerImpl) GetMyPostRevisions(c *gin.Context) {
+	slug := c.Param("post_slug")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "get post revisions", "you're not allowed to access this path", nil)
+		return
+	}
+
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get post revisions", err.Error(), nil)
+		return
+	}
+
+	revisions, pagination, ucErr := handler.uc.GetMyPostRevisions(c, username, slug, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get post revisions", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get post revisions", revisions, pagination)
+}
+
+//	@GetMyPostRevision	godoc
+//	@Summary			Get a revision of current user's post
+//	@Description		Get a single revision of current user's post, including its content.
+//	@Tags				Post
+//	@Security			BearerToken
+//	@Param				post_slug	path	string	true	"post's slug"
+//	@Param				revision	path	int		true	"revision number"
+//	@Produce			json
+//	@Success			200	{object}	helpers.ResponseWithData{data=dto.RevisionResponse}
+//	@Failure			404	{object}	helpers.ResponseWithError
+//	@Router				/blog/my/posts/{post_slug}/revisions/{revision} [GET]
+func (handler *postHandlerImpl) GetMyPostRevision(c *gin.Context) {
+	slug := c.Param("post_slug")
+	revision := c.Param("revision")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "get post revision", "you're not allowed to access this path", nil)
+		return
+	}
+
+	postRevision, err := handler.uc.GetMyPostRevision(c, username, slug, revision)
+	if err != nil {
+		helpers.ResponseBuilder(c, err.Code, "get post revision", err.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "get post revision", nil, postRevision)
+}
+
+//	@DiffMyPostRevisions	godoc
+//	@Summary				Compare two revisions of current user's post
+//	@Description			Get a line-level diff of the title and content between two revisions of current user's post.
+//	@Tags					Post
+//	@Security				BearerToken
+//	@Param					post_slug	path	string	true	"post's slug"
+//	@Param					from		query	int		true	"revision to compare from"
+//	@Param					to			query	int		true	"revision to compare to"
+//	@Produce				json
+//	@Success				200	{object}	helpers.ResponseWithData{data=dto.RevisionDiffResponse}
+//	@Failure				404	{object}	helpers.ResponseWithError
+//	@Router					/blog/my/posts/{post_slug}/revisions/diff [GET]
+func (handler *postHandlerImpl) DiffMyPostRevisions(c *gin.Context) {
+	slug := c.Param("post_slug")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "diff post revisions", "you're not allowed to access this path", nil)
+		return
+	}
+
+	diff, err := handler.uc.DiffMyPostRevisions(c, username, slug, c.Query("from"), c.Query("to"))
+	if err != nil {
+		helpers.ResponseBuilder(c, err.Code, "diff post revisions", err.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "diff post revisions", nil, diff)
+}
+
+//	@RestoreMyPostRevision	godoc
+//	@Summary				Restore a revision of current user's post
+//	@Description			Restore the title and content of an old revision. The restored state is saved as a new revision, older revisions are kept.
+//	@Tags					Post
+//	@Security				BearerToken
+//	@Param					post_slug	path	string	true	"post's slug"
+//	@Param					revision	path	int		true	"revision number to restore"
+//	@Produce				json
+//	@Success				200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure				404	{object}	helpers.ResponseWithError
+//	@Router					/blog/my/posts/{post_slug}/revisions/{revision}/restore [POST]
+func (handler *postHandlerImpl) RestoreMyPostRevision(c *gin.Context) {
+	slug := c.Param("post_slug")
+	revision := c.Param("revision")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "restore post revision", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := handler.uc.RestoreMyPostRevision(c, username, slug, revision)
+	if err != nil {
+		helpers.ResponseBuilder(c, err.Code, "restore post revision", err.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "restore post revision", nil, nil)
+}
+
+//	@PreviewPost	godoc
+//	@Summary		Preview a post's content
+//	@Description	Render Markdown content the same way a post's content is rendered, without saving anything.
+//	@Tags			Post
+//	@Param			Body	body	dto.PreviewRequest	true	"Markdown content to render"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.PreviewResponse}
+//	@Failure		400	{object}	helpers.ResponseWithError{error=[]helpers.InputError}
+//	@Router			/blog/my/posts/preview [post]
+func (handler *postHandlerImpl) PreviewPost(c *gin.Context) {
+	var data dto.PreviewRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "preview post", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "preview post", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.PreviewContent(c, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "preview post", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "preview post", nil, resp)
+}
@@ -5,7 +5,9 @@ import (
 	posthandler "goproject/internal/app/delivery/http/post/handler"
 	blogrepository "goproject/internal/app/repository/blog"
 	postrepository "goproject/internal/app/repository/post"
+	postrevisionrepository "goproject/internal/app/repository/postrevision"
 	postusecase "goproject/internal/app/usecase/post"
+	"goproject/internal/domain/policy"
 	"log/slog"
 
 	"github.com/gin-gonic/gin"
@@ -14,18 +16,30 @@ import (
 
 func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
 	postRepository := postrepository.NewPostRepository(db)
+	revisionRepository := postrevisionrepository.NewPostRevisionRepository(db)
 	blogRepository := blogrepository.NewBlogRepository(db)
-	usecase := postusecase.NewPostUsecase(postRepository, blogRepository, logger)
+	usecase := postusecase.NewPostUsecase(postRepository, revisionRepository, blogRepository, logger)
 	handler := posthandler.NewPostHandler(usecase)
 
-	r.GET("/blog/:username/posts", handler.GetPostsByBlogOwner)
-	r.GET("/blog/:username/posts/:post_slug", handler.GetPostBySlug)
+	canRead := middlewares.JWTAuthMiddleware(db, policy.ScopePostsRead)
+	canWrite := middlewares.JWTAuthMiddleware(db, policy.ScopePostsWrite)
+	redirectAlias := middlewares.RedirectUsernameAlias(db)
 
-	post := r.Group("/blog/my/posts", middlewares.JWTAuthMiddleware())
+	r.GET("/feed", canRead, handler.GetFeed)
+	r.GET("/blog/:username/posts", redirectAlias, handler.GetPostsByBlogOwner)
+	r.GET("/blog/:username/posts/:post_slug", redirectAlias, handler.GetPostBySlug)
+
+	post := r.Group("/blog/my/posts")
 	{
-		post.POST("", handler.CreateNewPost)
-		post.GET("", handler.GetAllMyBlogPosts)
-		post.PUT("/:post_slug", handler.UpdateMyPostBySlug)
-		post.DELETE("/:post_slug", handler.DeleteMyPostBySlug)
+		post.POST("", canWrite, middlewares.RequireVerifiedEmail(db), handler.CreateNewPost)
+		post.GET("", canRead, handler.GetAllMyBlogPosts)
+		post.POST("/preview", canWrite, handler.PreviewPost)
+		post.GET("/:post_slug", canRead, handler.GetMyPostBySlug)
+		post.PUT("/:post_slug", canWrite, middlewares.RequireVerifiedEmail(db), handler.UpdateMyPostBySlug)
+		post.DELETE("/:post_slug", canWrite, handler.DeleteMyPostBySlug)
+		post.GET("/:post_slug/revisions", canRead, handler.GetMyPostRevisions)
+		post.GET("/:post_slug/revisions/diff", canRead, handler.DiffMyPostRevisions)
+		post.GET("/:post_slug/revisions/:revision", canRead, handler.GetMyPostRevision)
+		post.POST("/:post_slug/revisions/:revision/restore", canWrite, middlewares.RequireVerifiedEmail(db), handler.RestoreMyPostRevision)
 	}
 }
@@ -0,0 +1,9 @@
+package dto
+
+type ReportRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+type CreateReportResponse struct {
+	ID uint `json:"report_id"`
+}
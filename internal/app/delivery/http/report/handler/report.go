@@ -0,0 +1,105 @@
+package reporthandler
+
+import (
+	"goproject/internal/app/delivery/http/report/dto"
+	reportusecase "goproject/internal/app/usecase/report"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler interface {
+	ReportPost(c *gin.Context)
+	ReportComment(c *gin.Context)
+}
+
+type reportHandlerImpl struct {
+	uc reportusecase.ReportUsecase
+}
+
+func NewReportHandler(uc reportusecase.ReportUsecase) ReportHandler {
+	return &reportHandlerImpl{
+		uc: uc,
+	}
+}
+
+//	@ReportPost		godoc
+//	@Summary		Report a post
+//	@Description	Report a published post to the moderators, e.g. for spam or abuse.
+//	@Description	Upon successful creation, it will returns the newly created report's ID.
+//	@Tags			Report
+//	@Param			Body		body	dto.ReportRequest	true	"why the post is being reported"
+//	@Param			username	path	string				true	"blog owner's username"
+//	@Param			post_slug	path	string				true	"post's slug"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		201	{object}	helpers.ResponseWithData{data=dto.CreateReportResponse}
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/blog/{username}/posts/{post_slug}/report [post]
+func (handler *reportHandlerImpl) ReportPost(c *gin.Context) {
+	var data dto.ReportRequest
+	blogOwner := c.Param("username")
+	postSlug := c.Param("post_slug")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "report post", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "report post", helpers.ValidationError(err), nil)
+		return
+	}
+
+	id, ucErr := handler.uc.ReportPost(c, data, username, blogOwner, postSlug)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "report post", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusCreated, "report post", nil, dto.CreateReportResponse{ID: id})
+}
+
+//	@ReportComment	godoc
+//	@Summary		Report a comment
+//	@Description	Report a comment on a post to the moderators, e.g. for spam or abuse.
+//	@Description	Upon successful creation, it will returns the newly created report's ID.
+//	@Tags			Report
+//	@Param			Body		body	dto.ReportRequest	true	"why the comment is being reported"
+//	@Param			username	path	string				true	"blog owner's username"
+//	@Param			post_slug	path	string				true	"post's slug"
+//	@Param			comment_id	path	int					true	"comment's ID"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		201	{object}	helpers.ResponseWithData{data=dto.CreateReportResponse}
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/blog/{username}/posts/{post_slug}/comments/{comment_id}/report [post]
+func (handler *reportHandlerImpl) ReportComment(c *gin.Context) {
+	var data dto.ReportRequest
+	blogOwner := c.Param("username")
+	postSlug := c.Param("post_slug")
+	commentID := c.Param("comment_id")
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "report comment", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "report comment", helpers.ValidationError(err), nil)
+		return
+	}
+
+	id, ucErr := handler.uc.ReportComment(c, data, username, blogOwner, postSlug, commentID)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "report comment", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusCreated, "report comment", nil, dto.CreateReportResponse{ID: id})
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"goproject/internal/app/delivery/http/middlewares"
+	reporthandler "goproject/internal/app/delivery/http/report/handler"
+	commentrepository "goproject/internal/app/repository/comment"
+	postrepository "goproject/internal/app/repository/post"
+	reportrepository "goproject/internal/app/repository/report"
+	reportusecase "goproject/internal/app/usecase/report"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	reportRepository := reportrepository.NewReportRepository(db)
+	postRepository := postrepository.NewPostRepository(db)
+	commentRepository := commentrepository.NewCommentRepository(db)
+	usecase := reportusecase.NewReportUsecase(reportRepository, postRepository, commentRepository, logger)
+	handler := reporthandler.NewReportHandler(usecase)
+
+	report := r.Group("/blog/:username/posts/:post_slug", middlewares.JWTAuthMiddleware(db))
+	{
+		report.POST("/report", handler.ReportPost)
+		report.POST("/comments/:comment_id/report", handler.ReportComment)
+	}
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "goproject/internal/app/delivery/http/post/dto"
+
+type PostSearchResponse struct {
+	dto.PostResponse
+	// Snippet is escaped HTML, only the matches are marked up, with <b> tags
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
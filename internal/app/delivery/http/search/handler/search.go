@@ -0,0 +1,54 @@
+package searchhandler
+
+import (
+	searchusecase "goproject/internal/app/usecase/search"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler interface {
+	SearchPosts(c *gin.Context)
+}
+
+type searchHandlerImpl struct {
+	uc searchusecase.SearchUsecase
+}
+
+func NewSearchHandler(uc searchusecase.SearchUsecase) SearchHandler {
+	return &searchHandlerImpl{
+		uc: uc,
+	}
+}
+
+//	@SearchPosts	godoc
+//	@Summary		Search posts
+//	@Description	Full-text search over the title and content of published posts, best matches first.
+//	@Description	The query supports web search syntax: "quoted phrases", OR, and -excluded words.
+//	@Description	Each result comes with a snippet of the matching content, with matched words wrapped in <b></b>.
+//	@Tags			Search
+//	@Param			q			query	string	true	"search query"
+//	@Param			username	query	string	false	"only search posts on this user's blog"
+//	@Param			limit		query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after		query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before		query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.PostSearchResponse}
+//	@Failure		400	{object}	helpers.ResponseWithError
+//	@Router			/search/posts [get]
+func (handler *searchHandlerImpl) SearchPosts(c *gin.Context) {
+	page, err := helpers.ParsePageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "search posts", err.Error(), nil)
+		return
+	}
+
+	results, pagination, ucErr := handler.uc.SearchPosts(c, c.Query("q"), c.Query("username"), page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "search posts", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "search posts", results, pagination)
+}
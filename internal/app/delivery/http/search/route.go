@@ -0,0 +1,19 @@
+package search
+
+import (
+	searchhandler "goproject/internal/app/delivery/http/search/handler"
+	postrepository "goproject/internal/app/repository/post"
+	searchusecase "goproject/internal/app/usecase/search"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	postRepository := postrepository.NewPostRepository(db)
+	usecase := searchusecase.NewSearchUsecase(postRepository, logger)
+	handler := searchhandler.NewSearchHandler(usecase)
+
+	r.GET("/search/posts", handler.SearchPosts)
+}
@@ -0,0 +1,7 @@
+package dto
+
+type TagResponse struct {
+	Name       string `json:"name"`
+	Slug       string `json:"tag_slug"`
+	NumOfPosts int64  `json:"num_of_posts"`
+}
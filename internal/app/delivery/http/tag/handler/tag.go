@@ -0,0 +1,75 @@
+package taghandler
+
+import (
+	tagusecase "goproject/internal/app/usecase/tag"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagHandler interface {
+	GetPostsByTag(c *gin.Context)
+	GetTagsByBlogOwner(c *gin.Context)
+}
+
+type tagHandlerImpl struct {
+	uc tagusecase.TagUsecase
+}
+
+func NewTagHandler(uc tagusecase.TagUsecase) TagHandler {
+	return &tagHandlerImpl{
+		uc: uc,
+	}
+}
+
+//	@GetPostsByTag	godoc
+//	@Summary		Get posts with a tag
+//	@Description	Get published posts from every blog that are tagged with the given tag.
+//	@Tags			Tag
+//	@Param			tag		path	string	true	"slug of the tag"
+//	@Param			limit	query	int		false	"number of items per page, 20 by default and 100 at most"
+//	@Param			after	query	string	false	"cursor of the next page, taken from pagination.next"
+//	@Param			before	query	string	false	"cursor of the previous page, taken from pagination.prev"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithPagination{data=[]dto.PostResponse}
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/tags/{tag}/posts [get]
+func (handler *tagHandlerImpl) GetPostsByTag(c *gin.Context) {
+	tag := c.Param("tag")
+
+	page, err := helpers.ParsePublishedPageRequest(c)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "get posts by tag", err.Error(), nil)
+		return
+	}
+
+	posts, pagination, ucErr := handler.uc.GetPostsByTag(c, tag, page)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get posts by tag", ucErr.String(), nil)
+		return
+	}
+
+	helpers.PaginatedResponseBuilder(c, http.StatusOK, "get posts by tag", posts, pagination)
+}
+
+//	@GetBlogTags	godoc
+//	@Summary		Get a blog's tags
+//	@Description	Get the tags used on a user's blog along with how many published posts have each tag, most used first.
+//	@Tags			Tag
+//	@Param			username	path	string	true	"user's username"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=[]dto.TagResponse}
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/blog/{username}/tags [get]
+func (handler *tagHandlerImpl) GetTagsByBlogOwner(c *gin.Context) {
+	username := c.Param("username")
+
+	tags, ucErr := handler.uc.GetTagsByBlogOwner(c, username)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get blog's tags", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "get blog's tags", nil, tags)
+}
@@ -0,0 +1,25 @@
+package tag
+
+import (
+	"goproject/internal/app/delivery/http/middlewares"
+	taghandler "goproject/internal/app/delivery/http/tag/handler"
+	blogrepository "goproject/internal/app/repository/blog"
+	postrepository "goproject/internal/app/repository/post"
+	tagrepository "goproject/internal/app/repository/tag"
+	tagusecase "goproject/internal/app/usecase/tag"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	tagRepository := tagrepository.NewTagRepository(db)
+	postRepository := postrepository.NewPostRepository(db)
+	blogRepository := blogrepository.NewBlogRepository(db)
+	usecase := tagusecase.NewTagUsecase(tagRepository, postRepository, blogRepository, logger)
+	handler := taghandler.NewTagHandler(usecase)
+
+	r.GET("/tags/:tag/posts", handler.GetPostsByTag)
+	r.GET("/blog/:username/tags", middlewares.RedirectUsernameAlias(db), handler.GetTagsByBlogOwner)
+}
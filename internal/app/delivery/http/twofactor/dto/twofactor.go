@@ -0,0 +1,19 @@
+package dto
+
+type EnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type CodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,162 @@
+package twofactorhandler
+
+import (
+	"goproject/internal/app/delivery/http/twofactor/dto"
+	twofactorusecase "goproject/internal/app/usecase/twofactor"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler interface {
+	Enroll(c *gin.Context)
+	Confirm(c *gin.Context)
+	Disable(c *gin.Context)
+	RegenerateRecoveryCodes(c *gin.Context)
+}
+
+type twoFactorHandlerImpl struct {
+	uc twofactorusecase.TwoFactorUsecase
+}
+
+func NewTwoFactorHandler(uc twofactorusecase.TwoFactorUsecase) TwoFactorHandler {
+	return &twoFactorHandlerImpl{
+		uc: uc,
+	}
+}
+
+// Enroll godoc
+//
+//	@Summary		Start enrolling in two-factor authentication
+//	@Description	Generate a new TOTP secret for current user, along with an otpauth:// URI to add it to an authenticator app.
+//	@Description	Two-factor authentication is only enabled once a code from the app is sent to /auth/2fa/confirm.
+//	@Tags			Two-Factor
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.EnrollResponse}
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/auth/2fa/enroll [post]
+func (handler *twoFactorHandlerImpl) Enroll(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "enroll two-factor", "you're not allowed to access this path", nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.Enroll(c, username)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "enroll two-factor", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "enroll two-factor", nil, resp)
+}
+
+// Confirm godoc
+//
+//	@Summary		Enable two-factor authentication
+//	@Description	Enable two-factor authentication with a code from the authenticator app the secret was added to.
+//	@Description	The response contains one-time recovery codes, they're only shown once.
+//	@Tags			Two-Factor
+//	@Param			Body	body	dto.CodeRequest	true	"code from the authenticator app"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.RecoveryCodesResponse}
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Router			/auth/2fa/confirm [post]
+func (handler *twoFactorHandlerImpl) Confirm(c *gin.Context) {
+	var data dto.CodeRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "confirm two-factor", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "confirm two-factor", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.Confirm(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "confirm two-factor", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "confirm two-factor", nil, resp)
+}
+
+// Disable godoc
+//
+//	@Summary		Disable two-factor authentication
+//	@Description	Disable two-factor authentication, both the password and a code (from the app or a recovery code) are required.
+//	@Tags			Two-Factor
+//	@Param			Body	body	dto.DisableRequest	true	"current password and a two-factor code"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/auth/2fa/disable [post]
+func (handler *twoFactorHandlerImpl) Disable(c *gin.Context) {
+	var data dto.DisableRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "disable two-factor", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "disable two-factor", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.Disable(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "disable two-factor", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "disable two-factor", nil, nil)
+}
+
+// RegenerateRecoveryCodes godoc
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Replace current user's recovery codes with new ones, the old ones stop working. Requires a code from the authenticator app.
+//	@Tags			Two-Factor
+//	@Param			Body	body	dto.CodeRequest	true	"code from the authenticator app"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.RecoveryCodesResponse}
+//	@Failure		401	{object}	helpers.ResponseWithError
+//	@Router			/auth/2fa/recovery-codes [post]
+func (handler *twoFactorHandlerImpl) RegenerateRecoveryCodes(c *gin.Context) {
+	var data dto.CodeRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "regenerate recovery codes", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "regenerate recovery codes", helpers.ValidationError(err), nil)
+		return
+	}
+
+	resp, ucErr := handler.uc.RegenerateRecoveryCodes(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "regenerate recovery codes", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "regenerate recovery codes", nil, resp)
+}
@@ -0,0 +1,28 @@
+package twofactor
+
+import (
+	"goproject/internal/app/delivery/http/middlewares"
+	twofactorhandler "goproject/internal/app/delivery/http/twofactor/handler"
+	recoverycoderepository "goproject/internal/app/repository/recoverycode"
+	userrepository "goproject/internal/app/repository/user"
+	twofactorusecase "goproject/internal/app/usecase/twofactor"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	userRepository := userrepository.NewUserRepository(db)
+	recoveryCodeRepository := recoverycoderepository.NewRecoveryCodeRepository(db)
+	usecase := twofactorusecase.NewTwoFactorUsecase(userRepository, recoveryCodeRepository, logger)
+	handler := twofactorhandler.NewTwoFactorHandler(usecase)
+
+	twoFactor := r.Group("/auth/2fa", middlewares.JWTAuthMiddleware(db))
+	{
+		twoFactor.POST("/enroll", handler.Enroll)
+		twoFactor.POST("/confirm", handler.Confirm)
+		twoFactor.POST("/disable", handler.Disable)
+		twoFactor.POST("/recovery-codes", handler.RegenerateRecoveryCodes)
+	}
+}
@@ -1,9 +1,49 @@
 package dto
 
+import "time"
+
 type UserResponse struct {
-	Name     string `json:"name"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	Name                string               `json:"name"`
+	Username            string               `json:"username"`
+	Email               string               `json:"email"`
+	EmailVerified       bool                 `json:"email_verified"`
+	TwoFactor           bool                 `json:"two_factor_enabled"`
+	DeletionScheduledAt *time.Time           `json:"deletion_scheduled_at,omitempty"`
+	Bio                 string               `json:"bio"`
+	Website             string               `json:"website"`
+	Location            string               `json:"location"`
+	SocialLinks         []SocialLinkResponse `json:"social_links"`
+	AvatarMediaID       *uint                `json:"avatar_media_id"`
+	Avatar              *AvatarResponse      `json:"avatar"`
+	Privacy             PrivacySettings      `json:"privacy"`
+}
+
+// ProfileResponse is what anyone can see about a user, the fields the user hid through their privacy settings are
+// left out.
+type ProfileResponse struct {
+	Username    string               `json:"username"`
+	Name        string               `json:"name"`
+	Bio         string               `json:"bio"`
+	Website     string               `json:"website"`
+	Location    string               `json:"location,omitempty"`
+	SocialLinks []SocialLinkResponse `json:"social_links"`
+	Avatar      *AvatarResponse      `json:"avatar"`
+	Followers   *int64               `json:"followers,omitempty"`
+	Following   *int64               `json:"following,omitempty"`
+	Posts       int64                `json:"posts"` // published ones
+	Comments    *int64               `json:"comments,omitempty"`
+	JoinedAt    time.Time            `json:"joined_at"`
+}
+
+// AvatarResponse is only there once the image is processed.
+type AvatarResponse struct {
+	URL    string `json:"url"`    // the largest variant
+	Srcset string `json:"srcset"` // every variant, for an <img> srcset attribute
+}
+
+type SocialLinkResponse struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
 }
 
 type UpdatePasswordRequest struct {
@@ -15,3 +55,34 @@ type UserUpdateInfoRequest struct {
 	Name  string `json:"name" binding:"required"`
 	Email string `json:"email" binding:"required,email"`
 }
+
+type UpdateUsernameRequest struct {
+	Username string `json:"username" binding:"required,min=6"`
+}
+
+// UpdateProfileRequest replaces the whole profile, the avatar is one of the user's uploads and is removed when it's
+// left out.
+type UpdateProfileRequest struct {
+	Bio           string              `json:"bio" binding:"max=500"`
+	Website       string              `json:"website" binding:"omitempty,http_url,max=255"`
+	Location      string              `json:"location" binding:"max=100"`
+	SocialLinks   []SocialLinkRequest `json:"social_links" binding:"max=5,dive"`
+	AvatarMediaID *uint               `json:"avatar_media_id"`
+}
+
+type SocialLinkRequest struct {
+	Label string `json:"label" binding:"required,max=50"`
+	URL   string `json:"url" binding:"required,http_url,max=255"`
+}
+
+type PrivacySettings struct {
+	ShowLocation bool `json:"show_location"`
+	ShowFollows  bool `json:"show_follows"`  // follower and following counts and lists
+	ShowActivity bool `json:"show_activity"` // comment count
+}
+
+type UpdatePrivacyRequest struct {
+	ShowLocation *bool `json:"show_location" binding:"required"`
+	ShowFollows  *bool `json:"show_follows" binding:"required"`
+	ShowActivity *bool `json:"show_activity" binding:"required"`
+}
@@ -13,6 +13,10 @@ type UserHandler interface {
 	GetMyInformation(c *gin.Context)
 	UpdateMyPassword(c *gin.Context)
 	UpdateMyInformation(c *gin.Context)
+	UpdateMyUsername(c *gin.Context)
+	GetUserProfile(c *gin.Context)
+	UpdateMyProfile(c *gin.Context)
+	UpdateMyPrivacy(c *gin.Context)
 }
 
 type userHandlerImpl struct {
@@ -25,6 +29,11 @@ func NewUserHandler(usecase userusecase.UserUsecase) UserHandler {
 	}
 }
 
+// filesURL is where the app serves uploaded files from, for avatars.
+func filesURL() string {
+	return helpers.APIURL("/media/files")
+}
+
 // MyInformation godoc
 //
 //	@Summary		Get current user information
@@ -41,7 +50,7 @@ func (handler *userHandlerImpl) GetMyInformation(c *gin.Context) {
 		return
 	}
 
-	user, err := handler.uc.GetUserDataByUsername(c, username)
+	user, err := handler.uc.GetUserDataByUsername(c, username, filesURL())
 	if err != nil {
 		helpers.ResponseBuilder(c, err.Code, "get my information", err.String(), nil)
 		return
@@ -54,6 +63,8 @@ func (handler *userHandlerImpl) GetMyInformation(c *gin.Context) {
 //
 //	@Summary		Change user password
 //	@Description	Change user password by providing required data
+//	@Description	Accounts created without a password have to set one through forgot password first.
+//	@Description	Every personal access token of the account is revoked, and every session but the current one.
 //	@Tags			User
 //	@Param			Body	body	dto.UpdatePasswordRequest	true	"the body to change user's password"
 //	@Security		BearerToken
@@ -75,7 +86,7 @@ func (handler *userHandlerImpl) UpdateMyPassword(c *gin.Context) {
 		return
 	}
 
-	ucErr := handler.uc.ChangePasswordByUsername(c, username, data)
+	ucErr := handler.uc.ChangePasswordByUsername(c, username, c.GetUint("session_id"), data)
 	if ucErr != nil {
 		helpers.ResponseBuilder(c, ucErr.Code, "update password", ucErr.String(), nil)
 		return
@@ -88,6 +99,7 @@ func (handler *userHandlerImpl) UpdateMyPassword(c *gin.Context) {
 //
 //	@Summary		Update current user's information
 //	@Description	Update current user's information by providing required data
+//	@Description	Changing the email sends a verification link to the new one, posts and comments are blocked until it's opened.
 //	@Tags			User
 //	@Param			Body	body	dto.UserUpdateInfoRequest	true	"the body to update user's information"
 //	@Security		BearerToken
@@ -109,7 +121,7 @@ func (handler *userHandlerImpl) UpdateMyInformation(c *gin.Context) {
 		return
 	}
 
-	ucErr := handler.uc.UpdateUserInformation(c, username, data)
+	ucErr := handler.uc.UpdateUserInformation(c, username, data, helpers.APIURL("/auth/verify"))
 	if ucErr != nil {
 		helpers.ResponseBuilder(c, ucErr.Code, "update information", ucErr.String(), nil)
 		return
@@ -117,3 +129,133 @@ func (handler *userHandlerImpl) UpdateMyInformation(c *gin.Context) {
 
 	helpers.ResponseBuilder(c, http.StatusOK, "update information", nil, nil)
 }
+
+// UpdateMyUsername godoc
+//
+//	@Summary		Change current user's username
+//	@Description	Rename the current user, their blog, posts and lists move to the new name.
+//	@Description	Links with the old name redirect to the new one, and nobody else can register it for 90 days.
+//	@Tags			User
+//	@Param			Body	body	dto.UpdateUsernameRequest	true	"the body to change user's username"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	map[string]any
+//	@Failure		409	{object}	map[string]any
+//	@Failure		429	{object}	map[string]any
+//	@Router			/users/me/username [put]
+func (handler *userHandlerImpl) UpdateMyUsername(c *gin.Context) {
+	var data dto.UpdateUsernameRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "update username", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "update username", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.ChangeUsername(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "update username", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "update username", nil, nil)
+}
+
+// GetUserProfile godoc
+//
+//	@Summary		Get a user's public profile
+//	@Description	Get a user's name, bio, links, avatar and how many published posts they have.
+//	@Description	Their location, follower and following counts and comment count are left out when they hid them, and their email never shows.
+//	@Tags			User
+//	@Param			username	path	string	true	"the user's username"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithData{data=dto.ProfileResponse}
+//	@Failure		404	{object}	helpers.ResponseWithError
+//	@Router			/users/{username} [get]
+func (handler *userHandlerImpl) GetUserProfile(c *gin.Context) {
+	username := c.Param("username")
+
+	profile, ucErr := handler.uc.GetProfile(c, username, filesURL())
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "get user profile", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "get user profile", nil, profile)
+}
+
+// UpdateMyProfile godoc
+//
+//	@Summary		Update current user's profile
+//	@Description	Replace current user's bio, website, location, social links and avatar.
+//	@Description	The avatar is one of the user's uploads, upload the image through the media endpoint first. Leaving it out removes the avatar.
+//	@Tags			User
+//	@Param			Body	body	dto.UpdateProfileRequest	true	"the body to update user's profile"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	map[string]any
+//	@Failure		404	{object}	map[string]any
+//	@Router			/users/me/profile [put]
+func (handler *userHandlerImpl) UpdateMyProfile(c *gin.Context) {
+	var data dto.UpdateProfileRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "update profile", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "update profile", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.UpdateProfile(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "update profile", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "update profile", nil, nil)
+}
+
+// UpdateMyPrivacy godoc
+//
+//	@Summary		Update current user's privacy settings
+//	@Description	Choose what the public profile shows: the location, the follower and following counts and lists, and the comment count.
+//	@Tags			User
+//	@Param			Body	body	dto.UpdatePrivacyRequest	true	"the body to update user's privacy settings"
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	map[string]any
+//	@Router			/users/me/privacy [put]
+func (handler *userHandlerImpl) UpdateMyPrivacy(c *gin.Context) {
+	var data dto.UpdatePrivacyRequest
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "update privacy", "you're not allowed to access this path", nil)
+		return
+	}
+
+	err := c.ShouldBindJSON(&data)
+	if err != nil {
+		helpers.ResponseBuilder(c, http.StatusBadRequest, "update privacy", helpers.ValidationError(err), nil)
+		return
+	}
+
+	ucErr := handler.uc.UpdatePrivacy(c, username, data)
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "update privacy", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "update privacy", nil, nil)
+}
@@ -3,8 +3,18 @@ package user
 import (
 	"goproject/internal/app/delivery/http/middlewares"
 	userhandler "goproject/internal/app/delivery/http/user/handler"
+	commentrepository "goproject/internal/app/repository/comment"
+	followrepository "goproject/internal/app/repository/follow"
+	mediarepository "goproject/internal/app/repository/media"
+	postrepository "goproject/internal/app/repository/post"
 	userrepository "goproject/internal/app/repository/user"
+	usernamealiasrepository "goproject/internal/app/repository/usernamealias"
+	usertokenrepository "goproject/internal/app/repository/usertoken"
 	userusecase "goproject/internal/app/usecase/user"
+	verificationusecase "goproject/internal/app/usecase/verification"
+	"goproject/internal/domain/policy"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/infrastructure/storage"
 
 	"log/slog"
 
@@ -12,17 +22,20 @@ import (
 	"gorm.io/gorm"
 )
 
-func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+func Route(r *gin.RouterGroup, db *gorm.DB, store storage.BlobStore, mailer mail.Mailer, logger *slog.Logger) {
 	repository := userrepository.NewUserRepository(db)
-	usecase := userusecase.NewUserUsecase(repository, logger)
+	verifier := verificationusecase.NewVerificationUsecase(repository, usertokenrepository.NewUserTokenRepository(db), mailer, logger)
+	usecase := userusecase.NewUserUsecase(repository, usernamealiasrepository.NewUsernameAliasRepository(db), followrepository.NewFollowRepository(db), postrepository.NewPostRepository(db), commentrepository.NewCommentRepository(db), mediarepository.NewMediaRepository(db), store, verifier, db, logger)
 	handler := userhandler.NewUserHandler(usecase)
 
 	user := r.Group("/users")
-	user.Use(middlewares.JWTAuthMiddleware())
 	{
-		user.GET("/me", handler.GetMyInformation)
-		user.PUT("/me", handler.UpdateMyInformation)
-		user.PUT("/me/update-password", handler.UpdateMyPassword)
-
+		user.GET("/me", middlewares.JWTAuthMiddleware(db, policy.ScopeProfileRead), handler.GetMyInformation)
+		user.PUT("/me", middlewares.JWTAuthMiddleware(db), handler.UpdateMyInformation)
+		user.PUT("/me/update-password", middlewares.JWTAuthMiddleware(db), handler.UpdateMyPassword)
+		user.PUT("/me/username", middlewares.JWTAuthMiddleware(db), handler.UpdateMyUsername)
+		user.PUT("/me/profile", middlewares.JWTAuthMiddleware(db), handler.UpdateMyProfile)
+		user.PUT("/me/privacy", middlewares.JWTAuthMiddleware(db), handler.UpdateMyPrivacy)
+		user.GET("/:username", middlewares.RedirectUsernameAlias(db), handler.GetUserProfile)
 	}
 }
@@ -0,0 +1,71 @@
+package verificationhandler
+
+import (
+	verificationusecase "goproject/internal/app/usecase/verification"
+	"goproject/internal/helpers"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VerificationHandler interface {
+	VerifyEmail(c *gin.Context)
+	ResendVerificationEmail(c *gin.Context)
+}
+
+type verificationHandlerImpl struct {
+	uc verificationusecase.VerificationUsecase
+}
+
+func NewVerificationHandler(uc verificationusecase.VerificationUsecase) VerificationHandler {
+	return &verificationHandlerImpl{
+		uc: uc,
+	}
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify email
+//	@Description	Confirm the email of an account with the token from the link sent on registration or email change.
+//	@Tags			Auth
+//	@Param			token	query	string	true	"verification token"
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		400	{object}	helpers.ResponseWithError
+//	@Router			/auth/verify [get]
+func (handler *verificationHandlerImpl) VerifyEmail(c *gin.Context) {
+	ucErr := handler.uc.VerifyEmail(c, c.Query("token"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "verify email", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "verify email", nil, nil)
+}
+
+// ResendVerificationEmail godoc
+//
+//	@Summary		Resend verification email
+//	@Description	Send a new verification link to current user's email, links sent before stop working.
+//	@Tags			Auth
+//	@Security		BearerToken
+//	@Produce		json
+//	@Success		200	{object}	helpers.ResponseWithoutDataAndError
+//	@Failure		409	{object}	helpers.ResponseWithError
+//	@Router			/auth/verify/resend [post]
+func (handler *verificationHandlerImpl) ResendVerificationEmail(c *gin.Context) {
+	username := c.GetString("username")
+
+	if username == "" {
+		helpers.ResponseBuilder(c, http.StatusUnauthorized, "resend verification email", "you're not allowed to access this path", nil)
+		return
+	}
+
+	ucErr := handler.uc.ResendVerificationEmail(c, username, helpers.APIURL("/auth/verify"))
+	if ucErr != nil {
+		helpers.ResponseBuilder(c, ucErr.Code, "resend verification email", ucErr.String(), nil)
+		return
+	}
+
+	helpers.ResponseBuilder(c, http.StatusOK, "resend verification email", nil, nil)
+}
@@ -0,0 +1,27 @@
+package verification
+
+import (
+	"goproject/internal/app/delivery/http/middlewares"
+	verificationhandler "goproject/internal/app/delivery/http/verification/handler"
+	userrepository "goproject/internal/app/repository/user"
+	usertokenrepository "goproject/internal/app/repository/usertoken"
+	verificationusecase "goproject/internal/app/usecase/verification"
+	"goproject/internal/infrastructure/mail"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func Route(r *gin.RouterGroup, db *gorm.DB, mailer mail.Mailer, logger *slog.Logger) {
+	userRepository := userrepository.NewUserRepository(db)
+	userTokenRepository := usertokenrepository.NewUserTokenRepository(db)
+	usecase := verificationusecase.NewVerificationUsecase(userRepository, userTokenRepository, mailer, logger)
+	handler := verificationhandler.NewVerificationHandler(usecase)
+
+	verification := r.Group("/auth/verify")
+	{
+		verification.GET("", handler.VerifyEmail)
+		verification.POST("/resend", middlewares.JWTAuthMiddleware(db), handler.ResendVerificationEmail)
+	}
+}
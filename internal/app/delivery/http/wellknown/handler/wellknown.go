@@ -0,0 +1,26 @@
+package wellknownhandler
+
+import (
+	"goproject/internal/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WellKnownHandler interface {
+	GetJWKS(c *gin.Context)
+}
+
+type wellKnownHandlerImpl struct{}
+
+func NewWellKnownHandler() WellKnownHandler {
+	return &wellKnownHandlerImpl{}
+}
+
+// GetJWKS serves the public keys JWTs are signed with as a JSON Web Key Set, so other services can verify them.
+// It lives outside the API base path, so it isn't part of the swagger docs.
+func (handler *wellKnownHandlerImpl) GetJWKS(c *gin.Context) {
+	// short enough for a newly added key to be picked up well before it starts signing
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, utils.JWKS())
+}
@@ -0,0 +1,16 @@
+package wellknown
+
+import (
+	wellknownhandler "goproject/internal/app/delivery/http/wellknown/handler"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Route has to be given the root group, well-known URIs live outside the API base path.
+func Route(r *gin.RouterGroup, db *gorm.DB, logger *slog.Logger) {
+	handler := wellknownhandler.NewWellKnownHandler()
+
+	r.GET("/.well-known/jwks.json", handler.GetJWKS)
+}
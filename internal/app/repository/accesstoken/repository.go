@@ -0,0 +1,59 @@
+package accesstokenrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type accessTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAccessTokenRepository(db *gorm.DB) repository.AccessTokenRepository {
+	return &accessTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *accessTokenRepositoryImpl) Create(ctx context.Context, data model.PersonalAccessToken) (*model.PersonalAccessToken, error) {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// FindByHash also loads the owner, whose role and suspension apply to the token too.
+func (repo *accessTokenRepositoryImpl) FindByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error) {
+	token := new(model.PersonalAccessToken)
+	err := repo.db.WithContext(ctx).Joins("User").First(token, "personal_access_tokens.token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (repo *accessTokenRepositoryImpl) FindByUsername(ctx context.Context, username string, page repository.PageRequest) ([]model.PersonalAccessToken, error) {
+	var tokens []model.PersonalAccessToken
+	err := repo.db.WithContext(ctx).Scopes(page.Scope("personal_access_tokens")).Find(&tokens, "username = ? AND revoked_at IS NULL", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (repo *accessTokenRepositoryImpl) Revoke(ctx context.Context, username string, tokenID uint) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.PersonalAccessToken{}).
+		Where("id = ? AND username = ? AND revoked_at IS NULL", tokenID, username).
+		Update("revoked_at", time.Now())
+	return res.RowsAffected, res.Error
+}
+
+func (repo *accessTokenRepositoryImpl) MarkUsed(ctx context.Context, tokenID uint) error {
+	err := repo.db.WithContext(ctx).Model(&model.PersonalAccessToken{}).Where("id = ?", tokenID).Update("last_used_at", time.Now()).Error
+	return err
+}
@@ -0,0 +1,24 @@
+package auditlogrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *auditLogRepositoryImpl) Create(ctx context.Context, data model.AuditLog) error {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	return err
+}
@@ -26,7 +26,7 @@ func (repo *blogRepositoryImpl) Create(ctx context.Context, data model.Blog, tx
 func (repo *blogRepositoryImpl) FindByOwner(ctx context.Context, owner string) (*model.Blog, error) {
 	blog := new(model.Blog)
 
-	err := repo.db.WithContext(ctx).Preload("User").Preload("Posts").First(blog, "owner = ?", owner).Error
+	err := repo.db.WithContext(ctx).Preload("User").First(blog, "owner = ?", owner).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,3 +43,9 @@ func (repo *blogRepositoryImpl) Update(ctx context.Context, data model.Blog) err
 	err := repo.db.WithContext(ctx).Model(&data).Updates(newData).Error
 	return err
 }
+
+func (repo *blogRepositoryImpl) CountPublishedPosts(ctx context.Context, blogID uint) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.Post{}).Where("blog_id = ? AND status = ?", blogID, model.PostStatusPublished).Count(&count).Error
+	return count, err
+}
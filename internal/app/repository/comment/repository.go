@@ -2,10 +2,13 @@ package commentrepository
 
 import (
 	"context"
+	"errors"
 	"goproject/internal/domain/model"
 	"goproject/internal/domain/repository"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type commentRepositoryImpl struct {
@@ -23,10 +26,10 @@ func (repo *commentRepositoryImpl) Create(ctx context.Context, data model.Commen
 	return data.ID, err
 }
 
-func (repo *commentRepositoryImpl) FindCommentByUsername(ctx context.Context, username string) ([]model.Comment, error) {
+func (repo *commentRepositoryImpl) FindCommentByUsername(ctx context.Context, username string, page repository.PageRequest) ([]model.Comment, error) {
 	var comments []model.Comment
 
-	err := repo.db.WithContext(ctx).Joins("Post.Blog").Find(&comments, "commenter=?", username).Error
+	err := repo.db.WithContext(ctx).Joins("Post.Blog").Scopes(page.Scope("comments")).Find(&comments, "commenter=? AND comments.deleted_at IS NULL", username).Error
 	if err != nil {
 		return nil, err
 	}
@@ -34,10 +37,19 @@ func (repo *commentRepositoryImpl) FindCommentByUsername(ctx context.Context, us
 	return comments, nil
 }
 
-func (repo *commentRepositoryImpl) FindCommentByPostID(ctx context.Context, PostID uint) ([]model.Comment, error) {
+// CountVisibleByCommenter counts the user's comments anyone can see: not deleted or hidden, on published posts.
+func (repo *commentRepositoryImpl) CountVisibleByCommenter(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.Comment{}).Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("commenter=? AND comments.deleted_at IS NULL AND comments.hidden_at IS NULL AND posts.status=?", username, model.PostStatusPublished).
+		Count(&count).Error
+	return count, err
+}
+
+func (repo *commentRepositoryImpl) FindRootCommentsByPostID(ctx context.Context, PostID uint, page repository.PageRequest) ([]model.Comment, error) {
 	var comments []model.Comment
 
-	err := repo.db.WithContext(ctx).Find(&comments, "post_id=?", PostID).Error
+	err := repo.db.WithContext(ctx).Scopes(page.Scope("comments")).Find(&comments, "post_id=? AND parent_id IS NULL", PostID).Error
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +57,127 @@ func (repo *commentRepositoryImpl) FindCommentByPostID(ctx context.Context, Post
 	return comments, nil
 }
 
+func (repo *commentRepositoryImpl) FindRepliesByRootIDs(ctx context.Context, rootIDs []uint, maxDepth int) ([]model.Comment, error) {
+	var comments []model.Comment
+	if len(rootIDs) == 0 || maxDepth < 1 {
+		return comments, nil
+	}
+
+	err := repo.db.WithContext(ctx).Raw(`WITH RECURSIVE thread AS (
+			SELECT comments.*, 1 AS depth FROM comments WHERE parent_id IN ?
+			UNION ALL
+			SELECT comments.*, thread.depth + 1 FROM comments JOIN thread ON comments.parent_id = thread.id WHERE thread.depth < ?
+		)
+		SELECT * FROM thread ORDER BY created_at ASC, id ASC`, rootIDs, maxDepth).Scan(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (repo *commentRepositoryImpl) CountRepliesByParentIDs(ctx context.Context, parentIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(parentIDs))
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ParentID uint
+		Count    int64
+	}
+	err := repo.db.WithContext(ctx).Model(&model.Comment{}).
+		Select("parent_id, COUNT(*) AS count").
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ParentID] = row.Count
+	}
+	return counts, nil
+}
+
+// Delete removes the comment along with any "[deleted]" ancestors that are left without replies.
+// A comment that still has replies is kept as a "[deleted]" tombstone instead, so the discussion under it stays readable.
 func (repo *commentRepositoryImpl) Delete(ctx context.Context, data model.Comment) error {
-	err := repo.db.WithContext(ctx).Delete(&data).Error
+	tx := repo.db.WithContext(ctx).Begin()
+
+	// the foreign key check of a new reply waits on this lock, otherwise a reply coming in after the count would be
+	// deleted along with the comment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Comment{}, "id=?", data.ID).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var replies int64
+	err = tx.Model(&model.Comment{}).Where("parent_id=?", data.ID).Count(&replies).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if replies > 0 {
+		err = tx.Model(&data).Updates(map[string]any{
+			"content":    "",
+			"deleted_at": time.Now(),
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
+	}
+
+	err = tx.Delete(&data).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for data.ParentID != nil {
+		parent := model.Comment{}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&parent, "id=?", *data.ParentID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			tx.Rollback()
+			return err
+		}
+
+		if parent.DeletedAt == nil {
+			break
+		}
+
+		err = tx.Model(&model.Comment{}).Where("parent_id=?", parent.ID).Count(&replies).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if replies > 0 {
+			break
+		}
+
+		err = tx.Delete(&parent).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		data = parent
+	}
+
+	return tx.Commit().Error
+}
+
+// SetHidden hides the comment when hiddenAt is set and unhides it when it's nil.
+func (repo *commentRepositoryImpl) SetHidden(ctx context.Context, data model.Comment, hiddenAt *time.Time) error {
+	err := repo.db.WithContext(ctx).Model(&data).Update("hidden_at", hiddenAt).Error
 	return err
 }
 
@@ -63,3 +194,13 @@ func (repo *commentRepositoryImpl) Update(ctx context.Context, data model.Commen
 	err := repo.db.WithContext(ctx).Updates(&data).Error
 	return err
 }
+
+// FindAllByCommenter returns every comment the user wrote and didn't delete, oldest first.
+func (repo *commentRepositoryImpl) FindAllByCommenter(ctx context.Context, username string) ([]model.Comment, error) {
+	var comments []model.Comment
+	err := repo.db.WithContext(ctx).Joins("Post.Blog").Order("comments.created_at, comments.id").Find(&comments, "commenter=? AND comments.deleted_at IS NULL", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
@@ -0,0 +1,59 @@
+package followrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type followRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) repository.FollowRepository {
+	return &followRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *followRepositoryImpl) Create(ctx context.Context, data model.Follow) error {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	return err
+}
+
+func (repo *followRepositoryImpl) Delete(ctx context.Context, follower, followee string) (int64, error) {
+	res := repo.db.WithContext(ctx).Delete(&model.Follow{}, "follower=? AND followee=?", follower, followee)
+	return res.RowsAffected, res.Error
+}
+
+func (repo *followRepositoryImpl) FindFollowers(ctx context.Context, username string, page repository.PageRequest) ([]model.Follow, error) {
+	var follows []model.Follow
+	err := repo.db.WithContext(ctx).Joins("FollowerUser").Scopes(page.Scope("follows")).Find(&follows, "followee=?", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return follows, nil
+}
+
+func (repo *followRepositoryImpl) FindFollowing(ctx context.Context, username string, page repository.PageRequest) ([]model.Follow, error) {
+	var follows []model.Follow
+	err := repo.db.WithContext(ctx).Joins("FolloweeUser").Scopes(page.Scope("follows")).Find(&follows, "follower=?", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return follows, nil
+}
+
+func (repo *followRepositoryImpl) CountFollowers(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.Follow{}).Where("followee=?", username).Count(&count).Error
+	return count, err
+}
+
+func (repo *followRepositoryImpl) CountFollowing(ctx context.Context, username string) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.Follow{}).Where("follower=?", username).Count(&count).Error
+	return count, err
+}
@@ -27,21 +27,48 @@ func (repo *listRepositoryImpl) Update(ctx context.Context, data model.List) err
 	newData := map[string]any{
 		"name":        data.Name,
 		"description": data.Description,
+		"is_public":   data.IsPublic,
 	}
 
 	err := repo.db.WithContext(ctx).Model(data).Updates(newData).Error
 	return err
 }
 
-func (repo *listRepositoryImpl) FindListsByOwner(ctx context.Context, username string) ([]model.List, error) {
+func (repo *listRepositoryImpl) FindListsByOwner(ctx context.Context, username string, page repository.PageRequest) ([]model.List, error) {
 	var lists []model.List
-	err := repo.db.WithContext(ctx).Preload("Posts").Find(&lists, "owner = ?", username).Error
+	err := repo.db.WithContext(ctx).Scopes(page.Scope("lists")).Find(&lists, "owner = ?", username).Error
 	if err != nil {
 		return nil, err
 	}
 	return lists, nil
 }
 
+func (repo *listRepositoryImpl) CountPublishedPostsByListIDs(ctx context.Context, listIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(listIDs))
+	if len(listIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ListID uint
+		Count  int64
+	}
+	err := repo.db.WithContext(ctx).Model(&model.ListPost{}).
+		Select("list_posts.list_id, COUNT(*) AS count").
+		Joins("JOIN posts ON posts.id = list_posts.post_id").
+		Where("list_posts.list_id IN ? AND posts.status = ?", listIDs, model.PostStatusPublished).
+		Group("list_posts.list_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ListID] = row.Count
+	}
+	return counts, nil
+}
+
 func (repo *listRepositoryImpl) FindListByOwnerAndListSlug(ctx context.Context, username string, listSlug string) (*model.List, error) {
 	list := new(model.List)
 	err := repo.db.WithContext(ctx).First(&list, "owner=? AND slug=?", username, listSlug).Error
@@ -51,15 +78,26 @@ func (repo *listRepositoryImpl) FindListByOwnerAndListSlug(ctx context.Context,
 	return list, nil
 }
 
-func (repo *listRepositoryImpl) FindPostsInAListByListSlug(ctx context.Context, username string, listSlug string) (*model.List, error) {
+func (repo *listRepositoryImpl) FindPostsInAListByListSlug(ctx context.Context, username string, listSlug string, page repository.PageRequest) (*model.List, error) {
 	list := new(model.List)
-	err := repo.db.WithContext(ctx).Preload("Posts.Blog.User").Preload("Posts").First(&list, "owner=? AND slug=?", username, listSlug).Error
+	err := repo.db.WithContext(ctx).Preload("Posts.Blog.User").Preload("Posts.Tags").Preload("Posts", func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", model.PostStatusPublished).Scopes(page.PublishedScope("posts"))
+	}).First(&list, "owner=? AND slug=?", username, listSlug).Error
 	if err != nil {
 		return nil, err
 	}
 	return list, nil
 }
 
+func (repo *listRepositoryImpl) FindPostInListBySlug(ctx context.Context, listID uint, postSlug string) (*model.Post, error) {
+	post := new(model.Post)
+	err := repo.db.WithContext(ctx).Joins("JOIN list_posts ON list_posts.post_id = posts.id").First(post, "list_posts.list_id=? AND posts.slug=?", listID, postSlug).Error
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
 func (repo *listRepositoryImpl) AddPostToList(ctx context.Context, postData model.Post, listData model.List) error {
 	// err := repo.db.WithContext(ctx).Model(&listData).Association("Posts").Append(&postData) // this won't throw an error on duplicate posts in the same list
 	err := repo.db.WithContext(ctx).Create(&model.ListPost{
@@ -89,3 +127,13 @@ func (repo *listRepositoryImpl) Delete(ctx context.Context, data model.List) err
 	tx.Commit()
 	return nil
 }
+
+// FindAllByOwner returns every list of the user, oldest first, along with all their posts whatever their status.
+func (repo *listRepositoryImpl) FindAllByOwner(ctx context.Context, username string) ([]model.List, error) {
+	var lists []model.List
+	err := repo.db.WithContext(ctx).Preload("Posts.Blog").Order("created_at, id").Find(&lists, "owner = ?", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
@@ -0,0 +1,81 @@
+package loginattemptrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+type memoryLoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]model.LoginAttempt
+}
+
+func NewMemoryLoginAttemptRepository() repository.LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{
+		attempts: make(map[string]model.LoginAttempt),
+	}
+}
+
+func (repo *memoryLoginAttemptRepository) Find(ctx context.Context, key string) (model.LoginAttempt, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	attempt, ok := repo.attempts[key]
+	if !ok {
+		return model.LoginAttempt{Key: key}, nil
+	}
+	return attempt, nil
+}
+
+func (repo *memoryLoginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (model.LoginAttempt, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	attempt, ok := repo.attempts[key]
+	if !ok || attempt.LastFailedAt.Before(now.Add(-window)) {
+		attempt.Key = key
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = now
+
+	repo.attempts[key] = attempt
+	return attempt, nil
+}
+
+func (repo *memoryLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	attempt, ok := repo.attempts[key]
+	if !ok {
+		return nil
+	}
+	attempt.Failures = 0
+	attempt.LockedUntil = &until
+	repo.attempts[key] = attempt
+	return nil
+}
+
+func (repo *memoryLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.attempts, key)
+	return nil
+}
+
+func (repo *memoryLoginAttemptRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for key, attempt := range repo.attempts {
+		if attempt.LastFailedAt.Before(before) && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(before)) {
+			delete(repo.attempts, key)
+		}
+	}
+	return nil
+}
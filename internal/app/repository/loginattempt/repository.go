@@ -0,0 +1,73 @@
+package loginattemptrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NewLoginAttemptRepository picks the store from LOGIN_ATTEMPT_STORE: database when it isn't set, or memory.
+// It's built once at startup and shared by everything counting failures. The memory store only works when a single
+// instance is running.
+func NewLoginAttemptRepository(db *gorm.DB) (repository.LoginAttemptRepository, error) {
+	switch store := os.Getenv("LOGIN_ATTEMPT_STORE"); store {
+	case "", "database":
+		return &loginAttemptRepositoryImpl{db: db}, nil
+	case "memory":
+		return NewMemoryLoginAttemptRepository(), nil
+	default:
+		return nil, fmt.Errorf("unknown LOGIN_ATTEMPT_STORE %s, must be database or memory", store)
+	}
+}
+
+type loginAttemptRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func (repo *loginAttemptRepositoryImpl) Find(ctx context.Context, key string) (model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := repo.db.WithContext(ctx).First(&attempt, "key = ?", key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.LoginAttempt{Key: key}, nil
+	}
+	return attempt, err
+}
+
+// RecordFailure is a single upsert, so concurrent failures can't overwrite each other's count.
+func (repo *loginAttemptRepositoryImpl) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	err := repo.db.WithContext(ctx).Raw(`INSERT INTO login_attempts (key, failures, last_failed_at) VALUES (@key, 1, @now)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failed_at < @since THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failed_at = EXCLUDED.last_failed_at
+		RETURNING *`, map[string]any{
+		"key":   key,
+		"now":   now,
+		"since": now.Add(-window),
+	}).Scan(&attempt).Error
+	return attempt, err
+}
+
+func (repo *loginAttemptRepositoryImpl) Lock(ctx context.Context, key string, until time.Time) error {
+	err := repo.db.WithContext(ctx).Model(&model.LoginAttempt{}).Where("key = ?", key).Updates(map[string]any{
+		"failures":     0,
+		"locked_until": until,
+	}).Error
+	return err
+}
+
+func (repo *loginAttemptRepositoryImpl) Reset(ctx context.Context, key string) error {
+	err := repo.db.WithContext(ctx).Delete(&model.LoginAttempt{}, "key = ?", key).Error
+	return err
+}
+
+func (repo *loginAttemptRepositoryImpl) DeleteStale(ctx context.Context, before time.Time) error {
+	err := repo.db.WithContext(ctx).Delete(&model.LoginAttempt{}, "last_failed_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, before).Error
+	return err
+}
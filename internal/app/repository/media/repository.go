@@ -0,0 +1,138 @@
+package mediarepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type mediaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) repository.MediaRepository {
+	return &mediaRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *mediaRepositoryImpl) Create(ctx context.Context, data model.Media) (*model.Media, error) {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (repo *mediaRepositoryImpl) FindByUsername(ctx context.Context, username string, page repository.PageRequest) ([]model.Media, error) {
+	var media []model.Media
+	err := repo.db.WithContext(ctx).Scopes(page.Scope("media")).Preload("Variants", variantsByWidth).Find(&media, "username = ?", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (repo *mediaRepositoryImpl) FindByIDAndUsername(ctx context.Context, id uint, username string) (*model.Media, error) {
+	media := new(model.Media)
+	err := repo.db.WithContext(ctx).Preload("Variants", variantsByWidth).First(media, "id = ? AND username = ?", id, username).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func variantsByWidth(db *gorm.DB) *gorm.DB {
+	return db.Order("width")
+}
+
+func (repo *mediaRepositoryImpl) FindVariantByKey(ctx context.Context, key string) (*model.MediaVariant, error) {
+	variant := new(model.MediaVariant)
+	err := repo.db.WithContext(ctx).First(variant, "key = ?", key).Error
+	if err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+func (repo *mediaRepositoryImpl) TotalSizeByUsername(ctx context.Context, username string) (int64, error) {
+	var total int64
+	err := repo.db.WithContext(ctx).Model(&model.Media{}).Where("username = ?", username).Select("COALESCE(SUM(size), 0)").Scan(&total).Error
+	return total, err
+}
+
+func (repo *mediaRepositoryImpl) Delete(ctx context.Context, data model.Media) error {
+	err := repo.db.WithContext(ctx).Delete(&data).Error
+	return err
+}
+
+func (repo *mediaRepositoryImpl) ClaimPending(ctx context.Context, staleBefore time.Time, limit int) ([]model.Media, error) {
+	var media []model.Media
+	err := repo.db.WithContext(ctx).Raw(`UPDATE media SET status = @processing, attempts = attempts + 1, updated_at = @now
+		WHERE id IN (
+			SELECT id FROM media
+			WHERE status = @pending OR (status = @processing AND updated_at < @staleBefore)
+			ORDER BY id LIMIT @limit
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, map[string]any{
+		"processing":  model.MediaStatusProcessing,
+		"pending":     model.MediaStatusPending,
+		"now":         time.Now(),
+		"staleBefore": staleBefore,
+		"limit":       limit,
+	}).Scan(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// SaveProcessed fails with gorm.ErrRecordNotFound when the media was deleted while it was being processed.
+func (repo *mediaRepositoryImpl) SaveProcessed(ctx context.Context, data model.Media, variants []model.MediaVariant) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	res := tx.Model(&model.Media{}).Where("id = ?", data.ID).Updates(map[string]any{
+		"status":           model.MediaStatusReady,
+		"processing_error": "",
+		"width":            data.Width,
+		"height":           data.Height,
+		"size":             data.Size,
+	})
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+
+	err := tx.Create(&variants).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (repo *mediaRepositoryImpl) UpdateStatus(ctx context.Context, id uint, status, processingError string) error {
+	err := repo.db.WithContext(ctx).Model(&model.Media{}).Where("id = ?", id).Updates(map[string]any{
+		"status":           status,
+		"processing_error": processingError,
+	}).Error
+	return err
+}
+
+func (repo *mediaRepositoryImpl) FindAllByUsername(ctx context.Context, username string) ([]model.Media, error) {
+	var media []model.Media
+	err := repo.db.WithContext(ctx).Preload("Variants", variantsByWidth).Order("created_at, id").Find(&media, "username = ?", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
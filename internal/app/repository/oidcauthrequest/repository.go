@@ -0,0 +1,47 @@
+package oidcauthrequestrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type oidcAuthRequestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOIDCAuthRequestRepository(db *gorm.DB) repository.OIDCAuthRequestRepository {
+	return &oidcAuthRequestRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *oidcAuthRequestRepositoryImpl) Create(ctx context.Context, data model.OIDCAuthRequest) error {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	return err
+}
+
+func (repo *oidcAuthRequestRepositoryImpl) Consume(ctx context.Context, stateHash string) (*model.OIDCAuthRequest, error) {
+	var requests []model.OIDCAuthRequest
+	err := repo.db.WithContext(ctx).Raw(`UPDATE oidc_auth_requests SET used_at = @now
+		WHERE state_hash = @stateHash AND used_at IS NULL
+		RETURNING *`, map[string]any{
+		"now":       time.Now(),
+		"stateHash": stateHash,
+	}).Scan(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &requests[0], nil
+}
+
+func (repo *oidcAuthRequestRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) error {
+	err := repo.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&model.OIDCAuthRequest{}).Error
+	return err
+}
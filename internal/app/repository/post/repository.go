@@ -2,12 +2,34 @@ package postrepository
 
 import (
 	"context"
+	"fmt"
 	"goproject/internal/domain/model"
 	"goproject/internal/domain/repository"
+	"html"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// The headline is taken from the raw content, which may hold HTML, so the matches are marked with characters that
+// can't be mistaken for markup, and only turned into <b> tags once the snippet is escaped. They're removed from the
+// content first so a post can't bring its own.
+const (
+	headlineStart = "\uE000"
+	headlineStop  = "\uE001"
+)
+
+var searchHeadlineOptions = fmt.Sprintf("MaxFragments=2, MinWords=15, MaxWords=35, FragmentDelimiter=\" ... \", StartSel=\"%s\", StopSel=\"%s\"", headlineStart, headlineStop)
+
+var headlineMarkers = strings.NewReplacer(headlineStart, "<b>", headlineStop, "</b>")
+
+// safeHeadline escapes the snippet ts_headline made, then puts the <b> tags around the matches.
+func safeHeadline(snippet string) string {
+	return headlineMarkers.Replace(html.EscapeString(snippet))
+}
+
 type postRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -18,35 +40,331 @@ func NewPostRepository(db *gorm.DB) repository.PostRepository {
 	}
 }
 
+// Create saves the post along with its first revision. Its tags only need a name and a slug, the missing ones are
+// created in the same transaction.
 func (repo *postRepositoryImpl) Create(ctx context.Context, data model.Post) error {
-	err := repo.db.WithContext(ctx).Create(&data).Error
-	return err
+	data.Revisions = []model.PostRevision{
+		{
+			Revision: 1,
+			Title:    data.Title,
+			Content:  data.Content,
+		},
+	}
+
+	tx := repo.db.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	tags, err := findOrCreateTags(tx, data.Tags)
+	if err != nil {
+		return err
+	}
+	data.Tags = tags
+
+	err = tx.Create(&data).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
 }
 
-func (repo *postRepositoryImpl) FindByBlogID(ctx context.Context, blogID uint) ([]model.Post, error) {
+func (repo *postRepositoryImpl) FindByBlogID(ctx context.Context, blogID uint, tag string, page repository.PageRequest) ([]model.Post, error) {
 	var posts []model.Post
-	err := repo.db.WithContext(ctx).Joins("Blog.User").Find(&posts, "blog_id=?", blogID).Error
+	err := repo.db.WithContext(ctx).Joins("Blog.User").Preload("Tags").Scopes(withTag(tag), page.Scope("posts")).Find(&posts, "blog_id=?", blogID).Error
 	if err != nil {
 		return nil, err
 	}
 	return posts, nil
 }
 
-func (repo *postRepositoryImpl) Update(ctx context.Context, data model.Post) error {
-	err := repo.db.WithContext(ctx).Updates(&data).Error
-	return err
+func (repo *postRepositoryImpl) FindPublishedByBlogID(ctx context.Context, blogID uint, tag string, page repository.PageRequest) ([]model.Post, error) {
+	var posts []model.Post
+	err := repo.db.WithContext(ctx).Joins("Blog.User").Preload("Tags").Scopes(withTag(tag), page.PublishedScope("posts")).Find(&posts, "blog_id=? AND status=?", blogID, model.PostStatusPublished).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (repo *postRepositoryImpl) FindPublishedByTagID(ctx context.Context, tagID uint, page repository.PageRequest) ([]model.Post, error) {
+	var posts []model.Post
+	err := repo.db.WithContext(ctx).Joins("Blog.User").Preload("Tags").
+		Joins("JOIN post_tags ON post_tags.post_id = posts.id").
+		Scopes(page.PublishedScope("posts")).
+		Find(&posts, "post_tags.tag_id=? AND status=?", tagID, model.PostStatusPublished).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindFeed returns published posts from the blogs of the users followed by username, latest published first.
+func (repo *postRepositoryImpl) FindFeed(ctx context.Context, username string, page repository.PageRequest) ([]model.Post, error) {
+	var posts []model.Post
+	followedBlogs := repo.db.Model(&model.Blog{}).Select("blogs.id").
+		Joins("JOIN follows ON follows.followee = blogs.owner").
+		Where("follows.follower = ?", username)
+
+	err := repo.db.WithContext(ctx).Joins("Blog.User").Preload("Tags").Scopes(page.PublishedScope("posts")).
+		Find(&posts, "posts.blog_id IN (?) AND status=?", followedBlogs, model.PostStatusPublished).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// withTag limits the query to posts tagged with the given tag slug, an empty slug doesn't filter anything.
+func withTag(tag string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if tag == "" {
+			return db
+		}
+		return db.Where("posts.id IN (?)", db.Session(&gorm.Session{NewDB: true}).Table("post_tags").
+			Select("post_tags.post_id").
+			Joins("JOIN tags ON tags.id = post_tags.tag_id").
+			Where("tags.slug = ?", tag))
+	}
+}
+
+// Update saves the post as a new revision. Its tags are replaced with tags unless it's nil, the tags only need a name
+// and a slug, like in Create.
+func (repo *postRepositoryImpl) Update(ctx context.Context, data model.Post, revision model.PostRevision, tags []model.Tag) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	// the post stays locked until the end, so two edits at once can't both take the next revision number
+	current := new(model.Post)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(current, "id=?", data.ID).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var latest uint
+	err = tx.Model(&model.PostRevision{}).Select("COALESCE(MAX(revision), 0)").Where("post_id=?", data.ID).Scan(&latest).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// posts written before revisions existed get their current state saved as the first revision
+	if latest == 0 {
+		err = tx.Create(&model.PostRevision{
+			PostID:    current.ID,
+			Revision:  1,
+			Title:     current.Title,
+			Content:   current.Content,
+			CreatedAt: current.UpdatedAt,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		latest = 1
+	}
+
+	newData := map[string]any{
+		"title":                data.Title,
+		"content":              data.Content,
+		"content_html":         data.ContentHTML,
+		"excerpt":              data.Excerpt,
+		"reading_time_minutes": data.ReadingTimeMinutes,
+		"render_version":       data.RenderVersion,
+		"status":               data.Status,
+		"published_at":         data.PublishedAt,
+	}
+
+	err = tx.Model(&data).Updates(newData).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	revision.PostID = data.ID
+	revision.Revision = latest + 1
+	revision.Title = data.Title
+	revision.Content = data.Content
+
+	err = tx.Create(&revision).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if tags != nil {
+		tags, err = findOrCreateTags(tx, tags)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Model(&data).Association("Tags").Replace(tags)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// findOrCreateTags returns the tags with the given slugs, creating the ones that don't exist yet. A tag created by
+// someone else in the meantime is found rather than duplicated.
+func findOrCreateTags(tx *gorm.DB, tags []model.Tag) ([]model.Tag, error) {
+	if len(tags) == 0 {
+		return []model.Tag{}, nil
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slug"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var found []model.Tag
+	err = tx.Find(&found, "slug IN ?", slugs).Error
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
 }
 
 func (repo *postRepositoryImpl) FindBySlugAndOwner(ctx context.Context, slug, owner string) (*model.Post, error) {
 	post := new(model.Post)
-	err := repo.db.WithContext(ctx).Joins("Blog.User").Joins("Blog").First(post, "slug=? AND owner=?", slug, owner).Error
+	err := repo.db.WithContext(ctx).Joins("Blog.User").Joins("Blog").Preload("Tags").First(post, "slug=? AND owner=?", slug, owner).Error
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (repo *postRepositoryImpl) FindPublishedBySlugAndOwner(ctx context.Context, slug, owner string) (*model.Post, error) {
+	post := new(model.Post)
+	err := repo.db.WithContext(ctx).Joins("Blog.User").Joins("Blog").Preload("Tags").First(post, "slug=? AND owner=? AND status=?", slug, owner, model.PostStatusPublished).Error
 	if err != nil {
 		return nil, err
 	}
 	return post, nil
 }
 
+func (repo *postRepositoryImpl) CountPublishedByOwner(ctx context.Context, owner string) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.Post{}).Joins("JOIN blogs ON blogs.id = posts.blog_id").Where("blogs.owner=? AND status=?", owner, model.PostStatusPublished).Count(&count).Error
+	return count, err
+}
+
 func (repo *postRepositoryImpl) Delete(ctx context.Context, post model.Post) error {
 	err := repo.db.WithContext(ctx).Delete(&post).Error
 	return err
 }
+
+func (repo *postRepositoryImpl) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.Post{}).
+		Where("status=? AND published_at<=?", model.PostStatusScheduled, now).
+		Update("status", model.PostStatusPublished)
+	return res.RowsAffected, res.Error
+}
+
+func (repo *postRepositoryImpl) FindRenderedBefore(ctx context.Context, version int, afterID uint, limit int) ([]model.Post, error) {
+	var posts []model.Post
+	err := repo.db.WithContext(ctx).Where("render_version < ? AND id > ?", version, afterID).Order("id").Limit(limit).Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// UpdateRendered only stores the rendered content, it isn't an edit so updated_at and the revisions are left alone.
+func (repo *postRepositoryImpl) UpdateRendered(ctx context.Context, data model.Post) error {
+	err := repo.db.WithContext(ctx).Model(&data).UpdateColumns(map[string]any{
+		"content_html":         data.ContentHTML,
+		"excerpt":              data.Excerpt,
+		"reading_time_minutes": data.ReadingTimeMinutes,
+		"render_version":       data.RenderVersion,
+	}).Error
+	return err
+}
+
+func (repo *postRepositoryImpl) Search(ctx context.Context, query, owner string, page repository.PageRequest) ([]repository.PostSearchResult, error) {
+	var hits []struct {
+		ID      uint
+		Rank    float64
+		Snippet string
+	}
+
+	rank := "ts_rank(posts.search_vector, query)"
+	db := repo.db.WithContext(ctx).Table("posts").
+		Select(fmt.Sprintf("posts.id, %s AS rank, ts_headline('english', translate(posts.content, ?, ''), query, ?) AS snippet", rank), headlineStart+headlineStop, searchHeadlineOptions).
+		Joins("CROSS JOIN websearch_to_tsquery('english', ?) AS query", query).
+		Where("posts.search_vector @@ query AND posts.status = ?", model.PostStatusPublished)
+
+	if owner != "" {
+		db = db.Where("posts.blog_id IN (?)", repo.db.Model(&model.Blog{}).Select("id").Where("owner = ?", owner))
+	}
+
+	switch {
+	case page.After != nil:
+		db = db.Where(fmt.Sprintf("(%s, posts.id) < (?, ?)", rank), page.After.Rank, page.After.ID).Order("rank DESC, posts.id DESC")
+	case page.Before != nil:
+		db = db.Where(fmt.Sprintf("(%s, posts.id) > (?, ?)", rank), page.Before.Rank, page.Before.ID).Order("rank ASC, posts.id ASC")
+	default:
+		db = db.Order("rank DESC, posts.id DESC")
+	}
+
+	err := db.Limit(page.Limit + 1).Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return []repository.PostSearchResult{}, nil
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	var posts []model.Post
+	err = repo.db.WithContext(ctx).Joins("Blog.User").Preload("Tags").Find(&posts, "posts.id IN ?", ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	postByID := make(map[uint]model.Post, len(posts))
+	for _, post := range posts {
+		postByID[post.ID] = post
+	}
+
+	results := make([]repository.PostSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		post, ok := postByID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, repository.PostSearchResult{
+			Post:    post,
+			Rank:    hit.Rank,
+			Snippet: safeHeadline(hit.Snippet),
+		})
+	}
+
+	return results, nil
+}
+
+// FindAllByBlogID returns every post of the blog, oldest first, along with their tags and revisions.
+func (repo *postRepositoryImpl) FindAllByBlogID(ctx context.Context, blogID uint) ([]model.Post, error) {
+	var posts []model.Post
+	err := repo.db.WithContext(ctx).Preload("Tags").Preload("Revisions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("revision")
+	}).Order("created_at, id").Find(&posts, "blog_id=?", blogID).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
@@ -0,0 +1,37 @@
+package postrevisionrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type postRevisionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPostRevisionRepository(db *gorm.DB) repository.PostRevisionRepository {
+	return &postRevisionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *postRevisionRepositoryImpl) FindByPostID(ctx context.Context, postID uint, page repository.PageRequest) ([]model.PostRevision, error) {
+	var revisions []model.PostRevision
+	err := repo.db.WithContext(ctx).Scopes(page.Scope("post_revisions")).Find(&revisions, "post_id=?", postID).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (repo *postRevisionRepositoryImpl) FindByPostIDAndRevision(ctx context.Context, postID, revision uint) (*model.PostRevision, error) {
+	postRevision := new(model.PostRevision)
+	err := repo.db.WithContext(ctx).First(postRevision, "post_id=? AND revision=?", postID, revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return postRevision, nil
+}
@@ -0,0 +1,52 @@
+package recoverycoderepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type recoveryCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) repository.RecoveryCodeRepository {
+	return &recoveryCodeRepositoryImpl{
+		db: db,
+	}
+}
+
+// Replace swaps every recovery code of the user for the given ones.
+func (repo *recoveryCodeRepositoryImpl) Replace(ctx context.Context, username string, codes []model.RecoveryCode) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	err := tx.Delete(&model.RecoveryCode{}, "username = ?", username).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Create(&codes).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Consume marks an unused code as used, it returns 0 when the user has no such code left.
+func (repo *recoveryCodeRepositoryImpl) Consume(ctx context.Context, username, codeHash string) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.RecoveryCode{}).
+		Where("username = ? AND code_hash = ? AND used_at IS NULL", username, codeHash).
+		Update("used_at", time.Now())
+	return res.RowsAffected, res.Error
+}
+
+func (repo *recoveryCodeRepositoryImpl) DeleteByUsername(ctx context.Context, username string) error {
+	err := repo.db.WithContext(ctx).Delete(&model.RecoveryCode{}, "username = ?", username).Error
+	return err
+}
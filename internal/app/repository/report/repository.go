@@ -0,0 +1,59 @@
+package reportrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) repository.ReportRepository {
+	return &reportRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *reportRepositoryImpl) Create(ctx context.Context, data model.Report) (uint, error) {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	return data.ID, err
+}
+
+func (repo *reportRepositoryImpl) FindByID(ctx context.Context, reportID uint) (*model.Report, error) {
+	report := new(model.Report)
+	err := repo.db.WithContext(ctx).First(report, "id=?", reportID).Error
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (repo *reportRepositoryImpl) FindAll(ctx context.Context, resolved bool, page repository.PageRequest) ([]model.Report, error) {
+	var reports []model.Report
+
+	db := repo.db.WithContext(ctx).Joins("Post.Blog").Preload("Comment").Scopes(page.Scope("reports"))
+	if resolved {
+		db = db.Where("reports.resolved_at IS NOT NULL")
+	} else {
+		db = db.Where("reports.resolved_at IS NULL")
+	}
+
+	err := db.Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (repo *reportRepositoryImpl) Resolve(ctx context.Context, data model.Report, resolvedBy string) error {
+	err := repo.db.WithContext(ctx).Model(&data).Updates(map[string]any{
+		"resolved_by": resolvedBy,
+		"resolved_at": time.Now(),
+	}).Error
+	return err
+}
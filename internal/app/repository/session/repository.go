@@ -0,0 +1,78 @@
+package sessionrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type sessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &sessionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *sessionRepositoryImpl) Create(ctx context.Context, data model.Session) (uint, error) {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	return data.ID, err
+}
+
+func (repo *sessionRepositoryImpl) FindByID(ctx context.Context, sessionID uint) (*model.Session, error) {
+	session := new(model.Session)
+	err := repo.db.WithContext(ctx).Joins("User").First(session, "sessions.id = ?", sessionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (repo *sessionRepositoryImpl) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	token := new(model.RefreshToken)
+	err := repo.db.WithContext(ctx).Joins("Session").First(token, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (repo *sessionRepositoryImpl) RotateRefreshToken(ctx context.Context, oldToken model.RefreshToken, newToken model.RefreshToken) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	// only mark the old token as used if nobody else did it first, otherwise two concurrent
+	// refreshes with the same token would both succeed
+	res := tx.Model(&model.RefreshToken{}).Where("id = ? AND used_at IS NULL", oldToken.ID).Update("used_at", time.Now())
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return repository.ErrRefreshTokenUsed
+	}
+
+	newToken.SessionID = oldToken.SessionID
+	err := tx.Create(&newToken).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (repo *sessionRepositoryImpl) Revoke(ctx context.Context, sessionID uint) error {
+	err := repo.db.WithContext(ctx).Model(&model.Session{}).Where("id = ? AND revoked_at IS NULL", sessionID).Update("revoked_at", time.Now()).Error
+	return err
+}
+
+func (repo *sessionRepositoryImpl) RevokeAllByUsername(ctx context.Context, username string) error {
+	err := repo.db.WithContext(ctx).Model(&model.Session{}).Where("username = ? AND revoked_at IS NULL", username).Update("revoked_at", time.Now()).Error
+	return err
+}
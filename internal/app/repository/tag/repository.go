@@ -0,0 +1,44 @@
+package tagrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type tagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) repository.TagRepository {
+	return &tagRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *tagRepositoryImpl) FindBySlug(ctx context.Context, slug string) (*model.Tag, error) {
+	tag := new(model.Tag)
+	err := repo.db.WithContext(ctx).First(tag, "slug = ?", slug).Error
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (repo *tagRepositoryImpl) CountPublishedPostsByBlogID(ctx context.Context, blogID uint) ([]repository.TagCount, error) {
+	var counts []repository.TagCount
+	err := repo.db.WithContext(ctx).Model(&model.Tag{}).
+		Select("tags.name, tags.slug, COUNT(posts.id) AS num_of_posts").
+		Joins("JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("JOIN posts ON posts.id = post_tags.post_id").
+		Where("posts.blog_id = ? AND posts.status = ?", blogID, model.PostStatusPublished).
+		Group("tags.id").
+		Order("num_of_posts DESC, tags.slug ASC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
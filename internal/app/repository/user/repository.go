@@ -4,8 +4,10 @@ import (
 	"context"
 	"goproject/internal/domain/model"
 	"goproject/internal/domain/repository"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepositoryImpl struct {
@@ -33,7 +35,295 @@ func (repo *userRepositoryImpl) FindByUsername(ctx context.Context, username str
 	return user, nil
 }
 
-func (repo *userRepositoryImpl) Update(ctx context.Context, data model.User) error {
-	err := repo.db.WithContext(ctx).Updates(data).Error
+func (repo *userRepositoryImpl) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	user := new(model.User)
+
+	err := repo.db.WithContext(ctx).First(user, "email = ?", email).Error
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (repo *userRepositoryImpl) UpdateName(ctx context.Context, username, name string) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("name", name).Error
+	return err
+}
+
+// UpdateEmail changes the user's email, which has to be verified again.
+func (repo *userRepositoryImpl) UpdateEmail(ctx context.Context, username, email string) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Updates(map[string]any{
+		"email":             email,
+		"email_verified_at": nil,
+	}).Error
+	return err
+}
+
+func (repo *userRepositoryImpl) UpdatePassword(ctx context.Context, username string, password []byte, keepSessionID uint) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	err := tx.Model(&model.User{}).Where("username = ?", username).Update("password", password).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Model(&model.Session{}).Where("username = ? AND id <> ? AND revoked_at IS NULL", username, keepSessionID).Update("revoked_at", time.Now()).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Model(&model.PersonalAccessToken{}).Where("username = ? AND revoked_at IS NULL", username).Update("revoked_at", time.Now()).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (repo *userRepositoryImpl) ResetPassword(ctx context.Context, token model.UserToken, password []byte) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	res := tx.Model(&model.UserToken{}).Where("id = ? AND used_at IS NULL", token.ID).Update("used_at", time.Now())
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return repository.ErrUserTokenUsed
+	}
+
+	err := tx.Model(&model.User{}).Where("username = ?", token.Username).Update("password", password).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// whoever knew the old password may still be logged in somewhere, or have made a token to get back in
+	err = tx.Model(&model.Session{}).Where("username = ? AND revoked_at IS NULL", token.Username).Update("revoked_at", time.Now()).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Model(&model.PersonalAccessToken{}).Where("username = ? AND revoked_at IS NULL", token.Username).Update("revoked_at", time.Now()).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (repo *userRepositoryImpl) UpdateProfile(ctx context.Context, data model.User, links []model.SocialLink) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	// a map, so emptied fields are saved too
+	err := tx.Model(&model.User{}).Where("username=?", data.Username).Updates(map[string]any{
+		"bio":             data.Bio,
+		"website":         data.Website,
+		"location":        data.Location,
+		"avatar_media_id": data.AvatarMediaID,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Where("username = ?", data.Username).Delete(&model.SocialLink{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if len(links) > 0 {
+		err = tx.Create(&links).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (repo *userRepositoryImpl) UpdatePrivacy(ctx context.Context, username string, settings model.PrivacySettings) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Updates(map[string]any{
+		"show_location": settings.ShowLocation,
+		"show_follows":  settings.ShowFollows,
+		"show_activity": settings.ShowActivity,
+	}).Error
 	return err
 }
+
+func (repo *userRepositoryImpl) FindSocialLinks(ctx context.Context, username string) ([]model.SocialLink, error) {
+	var links []model.SocialLink
+	err := repo.db.WithContext(ctx).Order("position").Find(&links, "username = ?", username).Error
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (repo *userRepositoryImpl) UpdateUsername(ctx context.Context, username, newUsername string, tx *gorm.DB) (int64, error) {
+	res := tx.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("username", newUsername)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.RowsAffected, res.Error
+	}
+
+	// audit logs and login attempts have no foreign key, so they're renamed here rather than by the cascade
+	err := tx.WithContext(ctx).Model(&model.AuditLog{}).Where("username=?", username).Update("username", newUsername).Error
+	if err != nil {
+		return 0, err
+	}
+	err = tx.WithContext(ctx).Model(&model.AuditLog{}).Where("actor=?", username).Update("actor", newUsername).Error
+	if err != nil {
+		return 0, err
+	}
+
+	// failures against the new name were made while no one had it, they don't belong to the user
+	err = tx.WithContext(ctx).Delete(&model.LoginAttempt{}, "key=?", model.UsernameAttemptKey(newUsername)).Error
+	if err != nil {
+		return 0, err
+	}
+	err = tx.WithContext(ctx).Model(&model.LoginAttempt{}).Where("key=?", model.UsernameAttemptKey(username)).Update("key", model.UsernameAttemptKey(newUsername)).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected, nil
+}
+
+func (repo *userRepositoryImpl) MarkEmailVerified(ctx context.Context, username string) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("email_verified_at", time.Now()).Error
+	return err
+}
+
+// SetTOTPSecret starts a new 2FA enrollment, 2FA stays off until it's enabled with a code made from this secret.
+func (repo *userRepositoryImpl) SetTOTPSecret(ctx context.Context, username, secret string) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Updates(map[string]any{
+		"totp_secret":     secret,
+		"totp_enabled_at": nil,
+		"totp_last_step":  0,
+	}).Error
+	return err
+}
+
+func (repo *userRepositoryImpl) EnableTOTP(ctx context.Context, username string) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("totp_enabled_at", time.Now()).Error
+	return err
+}
+
+func (repo *userRepositoryImpl) DisableTOTP(ctx context.Context, username string) error {
+	err := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Updates(map[string]any{
+		"totp_secret":     nil,
+		"totp_enabled_at": nil,
+		"totp_last_step":  0,
+	}).Error
+	return err
+}
+
+// UseTOTPStep records the time step of an accepted code. It returns 0 when that step, or a later one, was already used.
+func (repo *userRepositoryImpl) UseTOTPStep(ctx context.Context, username string, step int64) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=? AND totp_last_step < ?", username, step).Update("totp_last_step", step)
+	return res.RowsAffected, res.Error
+}
+
+func (repo *userRepositoryImpl) UpdateRole(ctx context.Context, username, role string) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("role", role)
+	return res.RowsAffected, res.Error
+}
+
+// UpdateSuspension suspends the user when suspendedAt is set and lifts the suspension when it's nil.
+func (repo *userRepositoryImpl) UpdateSuspension(ctx context.Context, username string, suspendedAt *time.Time) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("suspended_at", suspendedAt)
+	return res.RowsAffected, res.Error
+}
+
+// ScheduleDeletion schedules the user's deletion at deleteAt, or cancels it when it's nil.
+func (repo *userRepositoryImpl) ScheduleDeletion(ctx context.Context, username string, deleteAt *time.Time) (int64, error) {
+	res := repo.db.WithContext(ctx).Model(&model.User{}).Where("username=?", username).Update("deletion_scheduled_at", deleteAt)
+	return res.RowsAffected, res.Error
+}
+
+func (repo *userRepositoryImpl) FindDueForDeletion(ctx context.Context, now time.Time, limit int) ([]model.User, error) {
+	var users []model.User
+	err := repo.db.WithContext(ctx).Where("deletion_scheduled_at <= ?", now).Order("deletion_scheduled_at").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (repo *userRepositoryImpl) DeleteScheduled(ctx context.Context, username string, now time.Time) error {
+	tx := repo.db.WithContext(ctx).Begin()
+
+	// the row stays locked until the end, so the deletion can't be cancelled halfway through
+	var user model.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "username = ? AND deletion_scheduled_at <= ?", username, now).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	posts := tx.Model(&model.Post{}).Select("posts.id").Joins("JOIN blogs ON blogs.id = posts.blog_id").Where("blogs.owner = ?", username)
+	lists := tx.Model(&model.List{}).Select("id").Where("owner = ?", username)
+
+	// comments elsewhere are kept for the sake of the threads they're in, without their author
+	err = tx.Model(&model.Comment{}).Where("commenter = ?", username).UpdateColumn("commenter", nil).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// the user's posts disappear from other users' lists too
+	err = tx.Where("post_id IN (?) OR list_id IN (?)", posts, lists).Delete(&model.ListPost{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// revisions, tags, comments and reports go along with the posts
+	err = tx.Where("id IN (?)", posts).Delete(&model.Post{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Where("owner = ?", username).Delete(&model.Blog{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Where("owner = ?", username).Delete(&model.List{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Where("username = ?", username).Delete(&model.Session{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// the avatar is one of the media deleted along with the user, it's let go of first
+	err = tx.Model(&user).UpdateColumn("avatar_media_id", nil).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// the rest, from tokens to follows and media, is removed by the database along with the user
+	err = tx.Delete(&user).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,33 @@
+package useridentityrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+type userIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) repository.UserIdentityRepository {
+	return &userIdentityRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *userIdentityRepositoryImpl) Create(ctx context.Context, data model.UserIdentity, tx *gorm.DB) error {
+	err := tx.WithContext(ctx).Create(&data).Error
+	return err
+}
+
+func (repo *userIdentityRepositoryImpl) FindByIssuerAndSubject(ctx context.Context, issuer, subject string) (*model.UserIdentity, error) {
+	identity := new(model.UserIdentity)
+	err := repo.db.WithContext(ctx).First(identity, "issuer = ? AND subject = ?", issuer, subject).Error
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
@@ -0,0 +1,63 @@
+package usernamealiasrepository
+
+import (
+	"context"
+	"errors"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type usernameAliasRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUsernameAliasRepository(db *gorm.DB) repository.UsernameAliasRepository {
+	return &usernameAliasRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *usernameAliasRepositoryImpl) Create(ctx context.Context, data model.UsernameAlias, tx *gorm.DB) error {
+	err := tx.WithContext(ctx).Create(&data).Error
+	return err
+}
+
+func (repo *usernameAliasRepositoryImpl) FindByOldUsername(ctx context.Context, oldUsername string) (*model.UsernameAlias, error) {
+	alias := new(model.UsernameAlias)
+	err := repo.db.WithContext(ctx).First(alias, "old_username = ?", oldUsername).Error
+	if err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
+
+// CountCreatedSince counts the names the user gave up since the given time, as far as they're still reserved.
+func (repo *usernameAliasRepositoryImpl) CountCreatedSince(ctx context.Context, username string, since time.Time) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.UsernameAlias{}).Where("username = ? AND created_at >= ?", username, since).Count(&count).Error
+	return count, err
+}
+
+// Claim locks the alias until the transaction ends, so its owner can't take the name back while it's being claimed.
+func (repo *usernameAliasRepositoryImpl) Claim(ctx context.Context, username, claimant string, since time.Time, tx *gorm.DB) error {
+	alias := new(model.UsernameAlias)
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(alias, "old_username = ?", username).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if alias.Username != claimant && alias.CreatedAt.After(since) {
+		return repository.ErrUsernameReserved
+	}
+
+	// whoever takes the name gets its links, the alias stops redirecting
+	err = tx.WithContext(ctx).Delete(alias).Error
+	return err
+}
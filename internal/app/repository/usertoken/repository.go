@@ -0,0 +1,59 @@
+package usertokenrepository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type userTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) repository.UserTokenRepository {
+	return &userTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *userTokenRepositoryImpl) Create(ctx context.Context, data model.UserToken) error {
+	err := repo.db.WithContext(ctx).Create(&data).Error
+	return err
+}
+
+func (repo *userTokenRepositoryImpl) FindByHash(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error) {
+	token := new(model.UserToken)
+	err := repo.db.WithContext(ctx).First(token, "purpose = ? AND token_hash = ?", purpose, tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Consume marks the token as used, failing with repository.ErrUserTokenUsed when someone else already did.
+func (repo *userTokenRepositoryImpl) Consume(ctx context.Context, data model.UserToken) error {
+	res := repo.db.WithContext(ctx).Model(&model.UserToken{}).Where("id = ? AND used_at IS NULL", data.ID).Update("used_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrUserTokenUsed
+	}
+	return nil
+}
+
+// InvalidateAll marks every unused token of the user for the given purpose as used, so only the newest one works.
+func (repo *userTokenRepositoryImpl) InvalidateAll(ctx context.Context, username, purpose string) error {
+	err := repo.db.WithContext(ctx).Model(&model.UserToken{}).Where("username = ? AND purpose = ? AND used_at IS NULL", username, purpose).Update("used_at", time.Now()).Error
+	return err
+}
+
+// CountCreatedSince counts the tokens sent to the user for the given purpose since the given time, used or not.
+func (repo *userTokenRepositoryImpl) CountCreatedSince(ctx context.Context, username, purpose string, since time.Time) (int64, error) {
+	var count int64
+	err := repo.db.WithContext(ctx).Model(&model.UserToken{}).Where("username = ? AND purpose = ? AND created_at >= ?", username, purpose, since).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,123 @@
+package accesstokenusecase
+
+import (
+	"context"
+	"fmt"
+	"goproject/internal/app/delivery/http/accesstoken/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/utils"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type AccessTokenUsecase interface {
+	CreateToken(ctx context.Context, username string, data dto.CreateTokenRequest) (*dto.CreateTokenResponse, *helpers.Error)
+	GetMyTokens(ctx context.Context, username string, page repository.PageRequest) ([]dto.TokenResponse, *helpers.Pagination, *helpers.Error)
+	RevokeToken(ctx context.Context, username, tokenID string) *helpers.Error
+}
+
+// shown to users so they can tell their tokens apart, long enough to be distinct but useless on its own
+const tokenPrefixLength = 8
+
+type accessTokenUsecaseImpl struct {
+	repo   repository.AccessTokenRepository
+	logger *slog.Logger
+}
+
+func NewAccessTokenUsecase(repo repository.AccessTokenRepository, logger *slog.Logger) AccessTokenUsecase {
+	return &accessTokenUsecaseImpl{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+func (uc *accessTokenUsecaseImpl) CreateToken(ctx context.Context, username string, data dto.CreateTokenRequest) (*dto.CreateTokenResponse, *helpers.Error) {
+	random, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	token := utils.PersonalAccessTokenPrefix + random
+
+	scopes := slices.Clone(data.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	pat := model.PersonalAccessToken{
+		Username:  username,
+		Name:      data.Name,
+		Prefix:    token[:tokenPrefixLength],
+		TokenHash: utils.HashToken(token),
+		Scopes:    strings.Join(scopes, " "),
+	}
+	if data.ExpiresInDays != nil {
+		expiresAt := time.Now().AddDate(0, 0, *data.ExpiresInDays)
+		pat.ExpiresAt = &expiresAt
+	}
+
+	created, err := uc.repo.Create(ctx, pat)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return &dto.CreateTokenResponse{
+		TokenResponse: toTokenResponse(*created),
+		Token:         token,
+	}, nil
+}
+
+func (uc *accessTokenUsecaseImpl) GetMyTokens(ctx context.Context, username string, page repository.PageRequest) ([]dto.TokenResponse, *helpers.Pagination, *helpers.Error) {
+	tokensData := make([]dto.TokenResponse, 0)
+
+	tokens, err := uc.repo.FindByUsername(ctx, username, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	tokens, pagination := helpers.BuildPage(tokens, page, func(token model.PersonalAccessToken) repository.Cursor {
+		return repository.Cursor{CreatedAt: token.CreatedAt, ID: token.ID}
+	})
+	for _, token := range tokens {
+		tokensData = append(tokensData, toTokenResponse(token))
+	}
+
+	return tokensData, pagination, nil
+}
+
+func (uc *accessTokenUsecaseImpl) RevokeToken(ctx context.Context, username, tokenID string) *helpers.Error {
+	id, err := strconv.ParseUint(tokenID, 10, 64)
+	if err != nil {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "token id must be a positive integer")
+	}
+
+	n, err := uc.repo.Revoke(ctx, username, uint(id))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	if n == 0 {
+		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("token with id %s not found", tokenID))
+	}
+
+	return nil
+}
+
+func toTokenResponse(token model.PersonalAccessToken) dto.TokenResponse {
+	return dto.TokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     strings.Fields(token.Scopes),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
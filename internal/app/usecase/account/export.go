@@ -0,0 +1,328 @@
+package accountusecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/account/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/storage"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (uc *accountUsecaseImpl) ExportData(ctx context.Context, username string) (*dto.Export, *helpers.Error) {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	links, err := uc.userRepo.FindSocialLinks(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	socialLinks := make([]dto.ExportSocialLink, 0, len(links))
+	for _, link := range links {
+		socialLinks = append(socialLinks, dto.ExportSocialLink{
+			Label: link.Label,
+			URL:   link.URL,
+		})
+	}
+
+	export := dto.Export{
+		Profile: dto.ExportProfile{
+			Username:            user.Username,
+			Name:                user.Name,
+			Email:               user.Email,
+			EmailVerifiedAt:     user.EmailVerifiedAt,
+			Role:                user.Role,
+			TwoFactor:           user.TOTPEnabledAt != nil,
+			DeletionScheduledAt: user.DeletionScheduledAt,
+			Bio:                 user.Bio,
+			Website:             user.Website,
+			Location:            user.Location,
+			SocialLinks:         socialLinks,
+			AvatarMediaID:       user.AvatarMediaID,
+			ShowLocation:        user.ShowLocation,
+			ShowFollows:         user.ShowFollows,
+			ShowActivity:        user.ShowActivity,
+			CreatedAt:           user.CreatedAt,
+			UpdatedAt:           user.UpdatedAt,
+		},
+		Posts:    []dto.ExportPost{},
+		Comments: []dto.ExportComment{},
+		Lists:    []dto.ExportList{},
+		Media:    []dto.ExportMedia{},
+	}
+
+	blog, err := uc.blogRepo.FindByOwner(ctx, username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if blog != nil {
+		export.Blog = &dto.ExportBlog{
+			Name:        blog.Name,
+			Description: blog.Description,
+			CreatedAt:   blog.CreatedAt,
+			UpdatedAt:   blog.UpdatedAt,
+		}
+
+		posts, err := uc.postRepo.FindAllByBlogID(ctx, blog.ID)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+		for _, post := range posts {
+			export.Posts = append(export.Posts, newExportPost(post))
+		}
+	}
+
+	comments, err := uc.commentRepo.FindAllByCommenter(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	for _, comment := range comments {
+		export.Comments = append(export.Comments, dto.ExportComment{
+			ID:        comment.ID,
+			ParentID:  comment.ParentID,
+			PostURL:   postURL(comment.Post),
+			Comment:   comment.Content,
+			Hidden:    comment.HiddenAt != nil,
+			CreatedAt: comment.CreatedAt,
+			UpdatedAt: comment.UpdatedAt,
+		})
+	}
+
+	lists, err := uc.listRepo.FindAllByOwner(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	for _, list := range lists {
+		postURLs := make([]string, 0, len(list.Posts))
+		for _, post := range list.Posts {
+			postURLs = append(postURLs, postURL(post))
+		}
+		export.Lists = append(export.Lists, dto.ExportList{
+			Name:        list.Name,
+			Slug:        list.Slug,
+			Description: list.Description,
+			IsPublic:    list.IsPublic,
+			PostURLs:    postURLs,
+			CreatedAt:   list.CreatedAt,
+			UpdatedAt:   list.UpdatedAt,
+		})
+	}
+
+	media, err := uc.mediaRepo.FindAllByUsername(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	for _, m := range media {
+		export.Media = append(export.Media, newExportMedia(m))
+	}
+
+	return &export, nil
+}
+
+func newExportPost(post model.Post) dto.ExportPost {
+	tags := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	revisions := make([]dto.ExportRevision, 0, len(post.Revisions))
+	for _, revision := range post.Revisions {
+		revisions = append(revisions, dto.ExportRevision{
+			Revision:     revision.Revision,
+			Title:        revision.Title,
+			Content:      revision.Content,
+			RestoredFrom: revision.RestoredFrom,
+			CreatedAt:    revision.CreatedAt,
+		})
+	}
+
+	return dto.ExportPost{
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Content:     post.Content,
+		Status:      post.Status,
+		Tags:        tags,
+		PublishedAt: post.PublishedAt,
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   post.UpdatedAt,
+		Revisions:   revisions,
+	}
+}
+
+// newExportMedia lists the processed variants, or the upload itself when it hasn't been processed.
+func newExportMedia(media model.Media) dto.ExportMedia {
+	dir := fmt.Sprintf("media/%d", media.ID)
+
+	files := make([]dto.ExportMediaFile, 0, len(media.Variants))
+	for _, variant := range media.Variants {
+		files = append(files, dto.ExportMediaFile{
+			Path:  path.Join(dir, path.Base(variant.Key)),
+			Width: variant.Width,
+			Key:   variant.Key,
+		})
+	}
+	if len(files) == 0 {
+		files = append(files, dto.ExportMediaFile{
+			Path: path.Join(dir, path.Base(media.Key)),
+			Key:  media.Key,
+		})
+	}
+
+	return dto.ExportMedia{
+		ID:          media.ID,
+		Filename:    media.Filename,
+		ContentType: media.ContentType,
+		Status:      media.Status,
+		Width:       media.Width,
+		Height:      media.Height,
+		Files:       files,
+		CreatedAt:   media.CreatedAt,
+	}
+}
+
+func postURL(post model.Post) string {
+	return fmt.Sprintf("blog/%s/posts/%s", post.Blog.Owner, post.Slug)
+}
+
+// WriteExport writes the archive: a JSON file for each part of the export, each post and its revisions as Markdown
+// and the media files. The response has already started by the time files are read, so a file missing from the
+// store is left out of the archive instead of failing it.
+func (uc *accountUsecaseImpl) WriteExport(ctx context.Context, export dto.Export, w io.Writer) error {
+	err := uc.writeExport(ctx, export, w)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error(), "username", export.Profile.Username)
+	}
+	return err
+}
+
+func (uc *accountUsecaseImpl) writeExport(ctx context.Context, export dto.Export, w io.Writer) error {
+	archive := zip.NewWriter(w)
+	now := time.Now()
+
+	for i, media := range export.Media {
+		files := make([]dto.ExportMediaFile, 0, len(media.Files))
+		for _, file := range media.Files {
+			written, err := uc.writeBlob(ctx, archive, file, now)
+			if err != nil {
+				return err
+			}
+			if written {
+				files = append(files, file)
+			}
+		}
+		export.Media[i].Files = files
+	}
+
+	for _, post := range export.Posts {
+		err := writeFile(archive, fmt.Sprintf("posts/%s.md", post.Slug), now, postMarkdown(post.Title, post.Status, post.Tags, post.PublishedAt, post.Content))
+		if err != nil {
+			return err
+		}
+		for _, revision := range post.Revisions {
+			err := writeFile(archive, fmt.Sprintf("posts/%s/revisions/%d.md", post.Slug, revision.Revision), now, postMarkdown(revision.Title, "", nil, &revision.CreatedAt, revision.Content))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	parts := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", export.Profile},
+		{"blog.json", export.Blog},
+		{"posts.json", export.Posts},
+		{"comments.json", export.Comments},
+		{"lists.json", export.Lists},
+		{"media.json", export.Media},
+	}
+	for _, part := range parts {
+		data, err := json.MarshalIndent(part.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = writeFile(archive, part.name, now, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func (uc *accountUsecaseImpl) writeBlob(ctx context.Context, archive *zip.Writer, file dto.ExportMediaFile, modified time.Time) (bool, error) {
+	body, err := uc.store.Open(ctx, file.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			uc.logger.WarnContext(ctx, "media file missing from the export", "key", file.Key)
+			return false, nil
+		}
+		return false, err
+	}
+	defer body.Close()
+
+	// images are already compressed, they're stored as they are
+	dst, err := archive.CreateHeader(&zip.FileHeader{Name: file.Path, Method: zip.Store, Modified: modified})
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(dst, body)
+	return err == nil, err
+}
+
+func writeFile(archive *zip.Writer, name string, modified time.Time, data []byte) error {
+	dst, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = dst.Write(data)
+	return err
+}
+
+// postMarkdown puts the post's details in a front matter block above its content, the way static site generators
+// expect it. The values are JSON encoded, which YAML reads as well.
+func postMarkdown(title, status string, tags []string, publishedAt *time.Time, content string) []byte {
+	var buf bytes.Buffer
+	field := func(name string, value any) {
+		encoded, _ := json.Marshal(value)
+		fmt.Fprintf(&buf, "%s: %s\n", name, encoded)
+	}
+
+	buf.WriteString("---\n")
+	field("title", title)
+	if status != "" {
+		field("status", status)
+	}
+	if len(tags) > 0 {
+		field("tags", tags)
+	}
+	if publishedAt != nil {
+		field("date", publishedAt.Format(time.RFC3339))
+	}
+	buf.WriteString("---\n\n")
+	buf.WriteString(strings.TrimRight(content, "\n"))
+	buf.WriteString("\n")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,216 @@
+package accountusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/account/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/infrastructure/storage"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AccountUsecase interface {
+	// ExportData gathers everything the user has, WriteExport then writes it as a zip archive along with their files.
+	ExportData(ctx context.Context, username string) (*dto.Export, *helpers.Error)
+	WriteExport(ctx context.Context, export dto.Export, w io.Writer) error
+	ScheduleDeletion(ctx context.Context, username string) (*dto.DeletionResponse, *helpers.Error)
+	CancelDeletion(ctx context.Context, username string) *helpers.Error
+	DeleteScheduledAccounts(ctx context.Context) error
+}
+
+const (
+	defaultGracePeriodDays = 14
+	deletionBatchSize      = 20
+)
+
+// GracePeriodFromEnv reads how long users have to change their mind after asking for their account to be deleted
+// from ACCOUNT_DELETION_GRACE_DAYS.
+func GracePeriodFromEnv() (time.Duration, error) {
+	value := os.Getenv("ACCOUNT_DELETION_GRACE_DAYS")
+	if value == "" {
+		return defaultGracePeriodDays * 24 * time.Hour, nil
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid ACCOUNT_DELETION_GRACE_DAYS %s, must be a number of days", value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+type accountUsecaseImpl struct {
+	userRepo    repository.UserRepository
+	blogRepo    repository.BlogRepository
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	listRepo    repository.ListRepository
+	mediaRepo   repository.MediaRepository
+	store       storage.BlobStore
+	mailer      mail.Mailer
+	gracePeriod time.Duration
+	logger      *slog.Logger
+}
+
+func NewAccountUsecase(userRepo repository.UserRepository, blogRepo repository.BlogRepository, postRepo repository.PostRepository, commentRepo repository.CommentRepository, listRepo repository.ListRepository, mediaRepo repository.MediaRepository, store storage.BlobStore, mailer mail.Mailer, gracePeriod time.Duration, logger *slog.Logger) AccountUsecase {
+	return &accountUsecaseImpl{
+		userRepo:    userRepo,
+		blogRepo:    blogRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		listRepo:    listRepo,
+		mediaRepo:   mediaRepo,
+		store:       store,
+		mailer:      mailer,
+		gracePeriod: gracePeriod,
+		logger:      logger,
+	}
+}
+
+// ScheduleDeletion deletes the account once the grace period is over. Until then nothing changes, the user can keep
+// using the account and cancel the deletion.
+func (uc *accountUsecaseImpl) ScheduleDeletion(ctx context.Context, username string) (*dto.DeletionResponse, *helpers.Error) {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusConflict, fmt.Sprintf("your account is already going to be deleted on %s", user.DeletionScheduledAt.Format(time.RFC3339)))
+	}
+
+	deleteAt := time.Now().Add(uc.gracePeriod)
+	_, err := uc.userRepo.ScheduleDeletion(ctx, username, &deleteAt)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	uc.logger.InfoContext(ctx, "account deletion scheduled", "username", username, "delete_at", deleteAt)
+
+	// whoever asked for it may not be the owner, they're told either way
+	uc.sendEmail(mail.Message{
+		To:      user.Email,
+		Subject: "Your account is going to be deleted",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Your account (%s) is going to be deleted on %s, along with your blog, posts, lists and files. "+
+			"Your comments stay, without your name.\r\n\r\n"+
+			"If you changed your mind, or it wasn't you, log in and cancel the deletion before then. "+
+			"You can also download your data until then.\r\n",
+			user.Name, user.Username, deleteAt.Format(time.RFC1123)),
+	})
+
+	return &dto.DeletionResponse{DeletionScheduledAt: deleteAt}, nil
+}
+
+func (uc *accountUsecaseImpl) CancelDeletion(ctx context.Context, username string) *helpers.Error {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	if user.DeletionScheduledAt == nil {
+		return helpers.ErrorBuilder(http.StatusConflict, "your account isn't going to be deleted")
+	}
+
+	// once the deletion has started it waits for it to finish, and the user isn't found anymore
+	affected, err := uc.userRepo.ScheduleDeletion(ctx, username, nil)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	if affected == 0 {
+		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+	}
+
+	uc.logger.InfoContext(ctx, "account deletion cancelled", "username", username)
+
+	return nil
+}
+
+// DeleteScheduledAccounts deletes the accounts whose grace period is over. The files are removed after the records,
+// a file left behind only wastes storage.
+func (uc *accountUsecaseImpl) DeleteScheduledAccounts(ctx context.Context) error {
+	now := time.Now()
+
+	users, err := uc.userRepo.FindDueForDeletion(ctx, now, deletionBatchSize)
+	if err != nil {
+		return err
+	}
+
+	// a batch per run, an account that fails to be deleted is tried again next time instead of holding up the others
+	for _, user := range users {
+		media, err := uc.mediaRepo.FindAllByUsername(ctx, user.Username)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error(), "username", user.Username)
+			continue
+		}
+
+		err = uc.userRepo.DeleteScheduled(ctx, user.Username, now)
+		if err != nil {
+			// not found means the deletion was cancelled in the meantime
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				uc.logger.ErrorContext(ctx, err.Error(), "username", user.Username)
+			}
+			continue
+		}
+
+		for _, m := range media {
+			uc.deleteBlob(ctx, m.Key)
+			for _, variant := range m.Variants {
+				uc.deleteBlob(ctx, variant.Key)
+			}
+		}
+
+		uc.logger.InfoContext(ctx, "account deleted", "username", user.Username)
+
+		uc.sendEmail(mail.Message{
+			To:      user.Email,
+			Subject: "Your account has been deleted",
+			Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+				"Your account (%s) has been deleted as you asked. Thanks for having been around.\r\n",
+				user.Name, user.Username),
+		})
+	}
+
+	return nil
+}
+
+func (uc *accountUsecaseImpl) findUser(ctx context.Context, username string) (*model.User, *helpers.Error) {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	return user, nil
+}
+
+func (uc *accountUsecaseImpl) deleteBlob(ctx context.Context, key string) {
+	err := uc.store.Delete(ctx, key)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+	}
+}
+
+// sendEmail sends the message in the background, the request's context is gone by then so it can't be used.
+func (uc *accountUsecaseImpl) sendEmail(msg mail.Message) {
+	go func() {
+		err := uc.mailer.Send(context.Background(), msg)
+		if err != nil {
+			uc.logger.Error(err.Error())
+		}
+	}()
+}
@@ -0,0 +1,296 @@
+package adminusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/admin/dto"
+	lockoutusecase "goproject/internal/app/usecase/lockout"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/policy"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AdminUsecase interface {
+	SuspendUser(ctx context.Context, actor policy.Actor, username string) *helpers.Error
+	UnsuspendUser(ctx context.Context, username string) *helpers.Error
+	UnlockUser(ctx context.Context, actor policy.Actor, username, ip string) *helpers.Error
+	ChangeRole(ctx context.Context, actor policy.Actor, username string, data dto.ChangeRoleRequest) *helpers.Error
+	RemovePost(ctx context.Context, blogOwner, postSlug string) *helpers.Error
+	RemoveComment(ctx context.Context, commentID string) *helpers.Error
+	HideComment(ctx context.Context, commentID string) *helpers.Error
+	UnhideComment(ctx context.Context, commentID string) *helpers.Error
+	GetReports(ctx context.Context, status string, page repository.PageRequest) ([]dto.ReportResponse, *helpers.Pagination, *helpers.Error)
+	ResolveReport(ctx context.Context, actor policy.Actor, reportID string) *helpers.Error
+}
+
+type adminUsecaseImpl struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	reportRepo  repository.ReportRepository
+	lockout     lockoutusecase.LockoutUsecase
+	logger      *slog.Logger
+}
+
+func NewAdminUsecase(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, postRepo repository.PostRepository, commentRepo repository.CommentRepository, reportRepo repository.ReportRepository, lockout lockoutusecase.LockoutUsecase, logger *slog.Logger) AdminUsecase {
+	return &adminUsecaseImpl{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		reportRepo:  reportRepo,
+		lockout:     lockout,
+		logger:      logger,
+	}
+}
+
+func (uc *adminUsecaseImpl) SuspendUser(ctx context.Context, actor policy.Actor, username string) *helpers.Error {
+	if actor.Username == username {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "you can't suspend yourself")
+	}
+
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("user %s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// admins can't lock each other out, one has to be demoted first
+	if user.Role == model.RoleAdmin {
+		return helpers.ErrorBuilder(http.StatusForbidden, "admins can't be suspended")
+	}
+
+	now := time.Now()
+	_, err = uc.userRepo.UpdateSuspension(ctx, username, &now)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// existing sessions would otherwise keep working until their tokens expire
+	err = uc.sessionRepo.RevokeAllByUsername(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) UnsuspendUser(ctx context.Context, username string) *helpers.Error {
+	n, err := uc.userRepo.UpdateSuspension(ctx, username, nil)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if n == 0 {
+		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("user %s not found", username))
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) UnlockUser(ctx context.Context, actor policy.Actor, username, ip string) *helpers.Error {
+	_, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("user %s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return uc.lockout.Unlock(ctx, username, actor.Username, ip)
+}
+
+func (uc *adminUsecaseImpl) ChangeRole(ctx context.Context, actor policy.Actor, username string, data dto.ChangeRoleRequest) *helpers.Error {
+	// otherwise the last admin could demote themselves and leave nobody able to promote anyone
+	if actor.Username == username {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "you can't change your own role")
+	}
+
+	if !policy.IsValidRole(data.Role) {
+		return helpers.ErrorBuilder(http.StatusBadRequest, fmt.Sprintf("%s is not a valid role", data.Role))
+	}
+
+	n, err := uc.userRepo.UpdateRole(ctx, username, data.Role)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if n == 0 {
+		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("user %s not found", username))
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) RemovePost(ctx context.Context, blogOwner, postSlug string) *helpers.Error {
+	post, err := uc.postRepo.FindBySlugAndOwner(ctx, postSlug, blogOwner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.postRepo.Delete(ctx, *post)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) RemoveComment(ctx context.Context, commentID string) *helpers.Error {
+	comment, ucErr := uc.findComment(ctx, commentID)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	err := uc.commentRepo.Delete(ctx, *comment)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) HideComment(ctx context.Context, commentID string) *helpers.Error {
+	comment, ucErr := uc.findComment(ctx, commentID)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	now := time.Now()
+	err := uc.commentRepo.SetHidden(ctx, *comment, &now)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) UnhideComment(ctx context.Context, commentID string) *helpers.Error {
+	comment, ucErr := uc.findComment(ctx, commentID)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	err := uc.commentRepo.SetHidden(ctx, *comment, nil)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) GetReports(ctx context.Context, status string, page repository.PageRequest) ([]dto.ReportResponse, *helpers.Pagination, *helpers.Error) {
+	reportsData := make([]dto.ReportResponse, 0)
+
+	var resolved bool
+	switch status {
+	case "", "open":
+	case "resolved":
+		resolved = true
+	default:
+		return nil, nil, helpers.ErrorBuilder(http.StatusBadRequest, "status must be either open or resolved")
+	}
+
+	reports, err := uc.reportRepo.FindAll(ctx, resolved, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	reports, pagination := helpers.BuildPage(reports, page, reportCursor)
+
+	for _, report := range reports {
+		reportData := dto.ReportResponse{
+			ID:         report.ID,
+			Reporter:   report.Reporter,
+			PostURL:    fmt.Sprintf("blog/%s/posts/%s", report.Post.Blog.Owner, report.Post.Slug),
+			CommentID:  report.CommentID,
+			Reason:     report.Reason,
+			ResolvedBy: report.ResolvedBy,
+			ResolvedAt: report.ResolvedAt,
+			CreatedAt:  report.CreatedAt,
+		}
+		if report.Comment != nil {
+			reportData.Comment = report.Comment.Content
+		}
+		reportsData = append(reportsData, reportData)
+	}
+
+	return reportsData, pagination, nil
+}
+
+func (uc *adminUsecaseImpl) ResolveReport(ctx context.Context, actor policy.Actor, reportID string) *helpers.Error {
+	id, err := strconv.ParseUint(reportID, 10, 64)
+	if err != nil {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "report id must be a positive integer")
+	}
+
+	report, err := uc.reportRepo.FindByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("report with id %s not found", reportID))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if report.ResolvedAt != nil {
+		return helpers.ErrorBuilder(http.StatusConflict, "report has already been resolved")
+	}
+
+	err = uc.reportRepo.Resolve(ctx, *report, actor.Username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *adminUsecaseImpl) findComment(ctx context.Context, commentID string) (*model.Comment, *helpers.Error) {
+	id, err := strconv.ParseUint(commentID, 10, 64)
+	if err != nil {
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "comment id must be a positive integer")
+	}
+
+	comment, err := uc.commentRepo.FindCommentByID(ctx, uint(id))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if err != nil || comment.DeletedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("comment with id %s not found", commentID))
+	}
+
+	return comment, nil
+}
+
+func reportCursor(report model.Report) repository.Cursor {
+	return repository.Cursor{CreatedAt: report.CreatedAt, ID: report.ID}
+}
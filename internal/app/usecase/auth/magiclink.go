@@ -0,0 +1,206 @@
+package authusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/auth/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/utils"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// at most magicLinkLimit links are sent to the same account per magicLinkWindow, so the endpoint can't be used
+	// to flood someone's inbox
+	magicLinkLimit  = 5
+	magicLinkWindow = time.Hour
+)
+
+func (uc *authUsecaseImpl) SendMagicLink(ctx context.Context, data dto.MagicLinkRequest, loginURL string) *helpers.Error {
+	// like ForgotPassword, the response is the same whether the email is registered or not
+	user, err := uc.userRepo.FindByEmail(ctx, data.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if user.SuspendedAt != nil {
+		return nil
+	}
+
+	return uc.sendMagicLink(ctx, *user, loginURL)
+}
+
+// RegisterWithMagicLink creates the user along with their blog, the same way Register does, but without a password.
+// The email is verified by following the sign-in link, which is sent right away.
+func (uc *authUsecaseImpl) RegisterWithMagicLink(ctx context.Context, data dto.MagicLinkRegisterRequest, loginURL string) *helpers.Error {
+	userData := model.User{
+		Email:    data.Email,
+		Name:     data.Name,
+		Username: data.Username,
+	}
+
+	tx := uc.db.Begin()
+
+	err := uc.claimUsername(ctx, userData.Username, tx)
+	if err == nil {
+		err = uc.userRepo.Create(ctx, userData, tx)
+	}
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrDuplicatedKey) || errors.Is(err, repository.ErrUsernameReserved) {
+			return helpers.ErrorBuilder(http.StatusConflict, "username/email already used")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.blogRepo.Create(ctx, newBlog(userData), tx)
+	if err != nil {
+		tx.Rollback()
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// the account exists either way, a failed email can be asked for again through SendMagicLink
+	ucErr := uc.sendMagicLink(ctx, userData, loginURL)
+	if ucErr != nil {
+		uc.logger.WarnContext(ctx, "magic link not sent after registering", "username", userData.Username, "error", ucErr.String())
+	}
+
+	return nil
+}
+
+func (uc *authUsecaseImpl) LoginMagicLink(ctx context.Context, token string) (*dto.LoginResponse, *helpers.Error) {
+	if token == "" {
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "token is required")
+	}
+
+	userToken, err := uc.userTokenRepo.FindByHash(ctx, model.TokenPurposeMagicLogin, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "invalid sign-in link")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if userToken.UsedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "sign-in link has already been used, ask for a new one")
+	}
+
+	if time.Now().After(userToken.ExpiresAt) {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "sign-in link has expired, ask for a new one")
+	}
+
+	err = uc.userTokenRepo.Consume(ctx, *userToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenUsed) {
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "sign-in link has already been used, ask for a new one")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	user, err := uc.userRepo.FindByUsername(ctx, userToken.Username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if user.SuspendedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "your account has been suspended")
+	}
+
+	// the link could only be opened from the inbox, which is all verifying the email does
+	if user.EmailVerifiedAt == nil {
+		err = uc.userRepo.MarkEmailVerified(ctx, user.Username)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+	}
+
+	// the link stands in for the password, 2FA is still asked for
+	if user.TOTPEnabledAt != nil {
+		return uc.startTwoFactorChallenge(ctx, *user)
+	}
+
+	uc.lockout.RecordSuccess(ctx, user.Username)
+
+	return uc.startSession(ctx, *user)
+}
+
+// sendMagicLink emails the user a single-use link to loginURL, making the previous ones useless. Once the user got
+// magicLinkLimit links within magicLinkWindow, nothing is sent, without telling the caller, who may not be the user.
+func (uc *authUsecaseImpl) sendMagicLink(ctx context.Context, user model.User, loginURL string) *helpers.Error {
+	sent, err := uc.userTokenRepo.CountCreatedSince(ctx, user.Username, model.TokenPurposeMagicLogin, time.Now().Add(-magicLinkWindow))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	if sent >= magicLinkLimit {
+		uc.logger.WarnContext(ctx, "too many sign-in links asked for, not sending another", "username", user.Username)
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userTokenRepo.InvalidateAll(ctx, user.Username, model.TokenPurposeMagicLogin)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userTokenRepo.Create(ctx, model.UserToken{
+		Username:  user.Username,
+		Purpose:   model.TokenPurposeMagicLogin,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(magicLinkLifespan),
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Your sign-in link",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Open this link to log in to your account (%s):\r\n\r\n"+
+			"%s?token=%s\r\n\r\n"+
+			"It expires in %d minutes and can only be used once. If it wasn't you, you can ignore this email.\r\n",
+			user.Name, user.Username, loginURL, token, int(magicLinkLifespan.Minutes())),
+	}
+
+	// sent in the background, otherwise the response time would tell whether the email is registered.
+	// the request's context is gone by then, so it can't be used here
+	go func() {
+		err := uc.mailer.Send(context.Background(), msg)
+		if err != nil {
+			uc.logger.Error(err.Error())
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package authusecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/auth/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/oidc"
+	"goproject/internal/utils"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+const (
+	minUsernameLength = 6
+	maxUsernameLength = 30
+	// how many usernames are tried when provisioning before giving up, the first one is the preferred one
+	usernameAttempts = 5
+)
+
+func (uc *authUsecaseImpl) StartOIDCLogin(ctx context.Context, callbackURL string) (string, *helpers.Error) {
+	if uc.oidc == nil {
+		return "", helpers.ErrorBuilder(http.StatusNotFound, "single sign-on isn't set up")
+	}
+
+	// the verifier and the nonce stay here, only the state goes through the browser
+	var secrets [3]string
+	for i := range secrets {
+		secret, err := utils.GenerateRandomToken(32)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return "", helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+		secrets[i] = secret
+	}
+	state, codeVerifier, nonce := secrets[0], secrets[1], secrets[2]
+
+	err := uc.authRequestRepo.Create(ctx, model.OIDCAuthRequest{
+		StateHash:    utils.HashToken(state),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(oidcAuthRequestLifespan),
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return "", helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	authURL, err := uc.oidc.AuthCodeURL(ctx, callbackURL, state, nonce, codeVerifier)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return "", helpers.ErrorBuilder(http.StatusBadGateway, "the identity provider can't be reached, try again later")
+	}
+
+	return authURL, nil
+}
+
+func (uc *authUsecaseImpl) LoginOIDC(ctx context.Context, data dto.OIDCCallbackRequest, callbackURL string) (*dto.LoginResponse, *helpers.Error) {
+	if uc.oidc == nil {
+		return nil, helpers.ErrorBuilder(http.StatusNotFound, "single sign-on isn't set up")
+	}
+
+	// the request is used up whatever happens next, a code can only be tried once
+	authRequest, err := uc.authRequestRepo.Consume(ctx, utils.HashToken(data.State))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "invalid state, start logging in again")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if time.Now().After(authRequest.ExpiresAt) {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "the login took too long, start logging in again")
+	}
+
+	if data.Error != "" {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, strings.TrimSpace(fmt.Sprintf("the identity provider refused the login: %s %s", data.Error, data.ErrorDescription)))
+	}
+	if data.Code == "" {
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "code is required")
+	}
+
+	claims, err := uc.oidc.Exchange(ctx, callbackURL, data.Code, authRequest.CodeVerifier, authRequest.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidIDToken) {
+			uc.logger.WarnContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "the identity provider's answer couldn't be verified")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusBadGateway, "the identity provider can't be reached, try again later")
+	}
+
+	user, ucErr := uc.findOIDCUser(ctx, *claims)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	if user.SuspendedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "your account has been suspended")
+	}
+
+	// the provider vouches for who the user is, but 2FA set up here is still asked for
+	if user.TOTPEnabledAt != nil {
+		return uc.startTwoFactorChallenge(ctx, *user)
+	}
+
+	return uc.startSession(ctx, *user)
+}
+
+// findOIDCUser finds the user an identity is linked to. An identity seen for the first time is linked to the user
+// with the same email, as long as both the provider and this app verified it, otherwise anyone able to set an
+// unverified email at either end could take the account over. Without such a user, one is provisioned.
+func (uc *authUsecaseImpl) findOIDCUser(ctx context.Context, claims oidc.Claims) (*model.User, *helpers.Error) {
+	identity, err := uc.identityRepo.FindByIssuerAndSubject(ctx, claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err := uc.userRepo.FindByUsername(ctx, identity.Username)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "your identity provider account has no verified email")
+	}
+
+	identityData := model.UserIdentity{
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, claims.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if user != nil {
+		if user.EmailVerifiedAt == nil {
+			return nil, helpers.ErrorBuilder(http.StatusConflict, "an account with your email exists but the email isn't verified, verify it first or log in with your password")
+		}
+
+		identityData.Username = user.Username
+		err = uc.identityRepo.Create(ctx, identityData, uc.db)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+		return user, nil
+	}
+
+	if !uc.oidc.AutoProvision() {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "there's no account with your email, sign up first")
+	}
+
+	return uc.provisionOIDCUser(ctx, claims, identityData)
+}
+
+// provisionOIDCUser creates the user along with their blog, the same way Register does, and links the identity to
+// them. The email is already verified by the provider, and there's no password, the user can set one through the
+// forgot password flow.
+func (uc *authUsecaseImpl) provisionOIDCUser(ctx context.Context, claims oidc.Claims, identityData model.UserIdentity) (*model.User, *helpers.Error) {
+	base := usernameFromClaims(claims)
+	now := time.Now()
+
+	for attempt := 0; attempt < usernameAttempts; attempt++ {
+		username := base
+		if attempt > 0 {
+			suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+			if err != nil {
+				uc.logger.ErrorContext(ctx, err.Error())
+				return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+			}
+			username = fmt.Sprintf("%s_%04d", base[:min(len(base), maxUsernameLength-5)], suffix.Int64())
+		}
+
+		name := claims.Name
+		if name == "" {
+			name = username
+		}
+
+		userData := model.User{
+			Email:           claims.Email,
+			Name:            name,
+			Username:        username,
+			EmailVerifiedAt: &now,
+		}
+		identityData.Username = username
+
+		tx := uc.db.Begin()
+
+		err := uc.claimUsername(ctx, username, tx)
+		if errors.Is(err, repository.ErrUsernameReserved) {
+			tx.Rollback()
+			continue
+		}
+		if err == nil {
+			err = uc.userRepo.Create(ctx, userData, tx)
+		}
+		if err != nil {
+			tx.Rollback()
+			// the username is taken, or the email was registered in the meantime, which the next round tells apart
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				if _, err := uc.userRepo.FindByEmail(ctx, claims.Email); err == nil {
+					return nil, helpers.ErrorBuilder(http.StatusConflict, "an account with your email was just created, log in again")
+				}
+				continue
+			}
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		err = uc.blogRepo.Create(ctx, newBlog(userData), tx)
+		if err != nil {
+			tx.Rollback()
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		err = uc.identityRepo.Create(ctx, identityData, tx)
+		if err != nil {
+			tx.Rollback()
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		err = tx.Commit().Error
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		uc.logger.InfoContext(ctx, "provisioned user from single sign-on", "username", username, "issuer", claims.Issuer)
+
+		user, err := uc.userRepo.FindByUsername(ctx, username)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+		return user, nil
+	}
+
+	return nil, helpers.ErrorBuilder(http.StatusConflict, "couldn't find a free username for you, try again")
+}
+
+// usernameFromClaims turns the preferred username, or the email's local part, into a username: lowercase letters,
+// digits and underscores, padded to the minimum length.
+func usernameFromClaims(claims oidc.Claims) string {
+	source := claims.PreferredUsername
+	if source == "" {
+		source, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(source) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		case r == '_' || r == '.' || r == '-':
+			b.WriteRune('_')
+		}
+	}
+
+	username := strings.Trim(b.String(), "_")
+	if len(username) > maxUsernameLength {
+		username = username[:maxUsernameLength]
+	}
+	if len(username) < minUsernameLength {
+		username = "user_" + username
+		username += strings.Repeat("0", max(0, minUsernameLength-len(username)))
+	}
+	return username
+}
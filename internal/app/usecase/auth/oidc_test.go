@@ -0,0 +1,493 @@
+package authusecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"goproject/internal/app/delivery/http/auth/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/infrastructure/oidc"
+	"goproject/internal/infrastructure/oidc/oidctest"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const oidcCallbackURL = "http://app.test/auth/oidc/callback"
+
+// fakeDB stands in for the database connection, the repositories are fakes so it only has to keep track of the
+// transactions the usecase runs.
+type fakeDB struct {
+	mu  sync.Mutex
+	txs []*fakeTx
+}
+
+type fakeTx struct {
+	fakeDB
+	committed  bool
+	rolledBack bool
+}
+
+var errNoDatabase = errors.New("the fake database can't run queries")
+
+func (db *fakeDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (db *fakeDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (db *fakeDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (db *fakeDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return nil
+}
+
+func (db *fakeDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx := new(fakeTx)
+	db.mu.Lock()
+	db.txs = append(db.txs, tx)
+	db.mu.Unlock()
+	return tx, nil
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+// txOf is the transaction a repository was given, nil when it was given the connection itself.
+func txOf(db *gorm.DB) *fakeTx {
+	tx, _ := db.Statement.ConnPool.(*fakeTx)
+	return tx
+}
+
+type fakeUserRepository struct {
+	repository.UserRepository
+	users   map[string]model.User
+	created map[string]*fakeTx
+}
+
+func (repo *fakeUserRepository) Create(ctx context.Context, data model.User, tx *gorm.DB) error {
+	for _, user := range repo.users {
+		if user.Username == data.Username || user.Email == data.Email {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	repo.users[data.Username] = data
+	repo.created[data.Username] = txOf(tx)
+	return nil
+}
+
+func (repo *fakeUserRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	user, ok := repo.users[username]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+func (repo *fakeUserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	for _, user := range repo.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeBlogRepository struct {
+	repository.BlogRepository
+	blogs   map[string]model.Blog
+	created map[string]*fakeTx
+}
+
+func (repo *fakeBlogRepository) Create(ctx context.Context, data model.Blog, tx *gorm.DB) error {
+	repo.blogs[data.Owner] = data
+	repo.created[data.Owner] = txOf(tx)
+	return nil
+}
+
+type fakeSessionRepository struct {
+	repository.SessionRepository
+	sessions []model.Session
+}
+
+func (repo *fakeSessionRepository) Create(ctx context.Context, data model.Session) (uint, error) {
+	repo.sessions = append(repo.sessions, data)
+	return uint(len(repo.sessions)), nil
+}
+
+type fakeIdentityRepository struct {
+	identities []model.UserIdentity
+	created    []*fakeTx
+}
+
+func (repo *fakeIdentityRepository) Create(ctx context.Context, data model.UserIdentity, tx *gorm.DB) error {
+	repo.identities = append(repo.identities, data)
+	repo.created = append(repo.created, txOf(tx))
+	return nil
+}
+
+func (repo *fakeIdentityRepository) FindByIssuerAndSubject(ctx context.Context, issuer, subject string) (*model.UserIdentity, error) {
+	for _, identity := range repo.identities {
+		if identity.Issuer == issuer && identity.Subject == subject {
+			return &identity, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeAuthRequestRepository struct {
+	repository.OIDCAuthRequestRepository
+	requests map[string]model.OIDCAuthRequest
+}
+
+func (repo *fakeAuthRequestRepository) Create(ctx context.Context, data model.OIDCAuthRequest) error {
+	repo.requests[data.StateHash] = data
+	return nil
+}
+
+func (repo *fakeAuthRequestRepository) Consume(ctx context.Context, stateHash string) (*model.OIDCAuthRequest, error) {
+	request, ok := repo.requests[stateHash]
+	if !ok || request.UsedAt != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	request.UsedAt = &now
+	repo.requests[stateHash] = request
+	return &request, nil
+}
+
+type fakeAliasRepository struct {
+	repository.UsernameAliasRepository
+	reserved map[string]bool
+}
+
+func (repo *fakeAliasRepository) Claim(ctx context.Context, username, claimant string, since time.Time, tx *gorm.DB) error {
+	if repo.reserved[username] {
+		return repository.ErrUsernameReserved
+	}
+	return nil
+}
+
+type oidcTest struct {
+	uc         AuthUsecase
+	idp        *oidctest.Provider
+	db         *fakeDB
+	users      *fakeUserRepository
+	blogs      *fakeBlogRepository
+	sessions   *fakeSessionRepository
+	identities *fakeIdentityRepository
+	aliases    *fakeAliasRepository
+}
+
+func newOIDCTest(t *testing.T, autoProvision bool) *oidcTest {
+	t.Helper()
+
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("JWT_LIFESPAN_MINUTES", "1")
+	t.Setenv("REFRESH_TOKEN_LIFESPAN", "1")
+
+	idp, err := oidctest.NewProvider("blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(idp.Close)
+
+	provider, err := oidc.NewClient(oidc.Config{
+		Issuer:        idp.Issuer,
+		ClientID:      "blog",
+		Scopes:        []string{"openid", "email", "profile"},
+		AutoProvision: autoProvision,
+	}, idp.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fdb := new(fakeDB)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: fdb}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test := &oidcTest{
+		idp:        idp,
+		db:         fdb,
+		users:      &fakeUserRepository{users: map[string]model.User{}, created: map[string]*fakeTx{}},
+		blogs:      &fakeBlogRepository{blogs: map[string]model.Blog{}, created: map[string]*fakeTx{}},
+		sessions:   new(fakeSessionRepository),
+		identities: new(fakeIdentityRepository),
+		aliases:    &fakeAliasRepository{reserved: map[string]bool{}},
+	}
+	authRequests := &fakeAuthRequestRepository{requests: map[string]model.OIDCAuthRequest{}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	test.uc = NewAuthUsecase(test.users, test.blogs, test.sessions, nil, test.identities, authRequests, test.aliases, nil, nil, nil, provider, nil, db, logger)
+	return test
+}
+
+// login goes through the flow the way the browser does, and returns what the callback gets back.
+func (test *oidcTest) login(t *testing.T, claims jwt.MapClaims) (*dto.LoginResponse, int) {
+	t.Helper()
+
+	ctx := context.Background()
+	authURL, ucErr := test.uc.StartOIDCLogin(ctx, oidcCallbackURL)
+	if ucErr != nil {
+		t.Fatal(ucErr.String())
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := test.idp.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, ucErr := test.uc.LoginOIDC(ctx, dto.OIDCCallbackRequest{State: u.Query().Get("state"), Code: code}, oidcCallbackURL)
+	if ucErr != nil {
+		return nil, ucErr.Code
+	}
+	return resp, http.StatusOK
+}
+
+func (test *oidcTest) addUser(user model.User) {
+	test.users.users[user.Username] = user
+}
+
+func TestLoginOIDCLinksVerifiedEmail(t *testing.T) {
+	test := newOIDCTest(t, true)
+	verifiedAt := time.Now()
+	test.addUser(model.User{Username: "janedoe", Email: "jane@example.com", Name: "Jane", EmailVerifiedAt: &verifiedAt})
+
+	resp, code := test.login(t, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": true})
+	if code != http.StatusOK {
+		t.Fatalf("LoginOIDC() = %d, want %d", code, http.StatusOK)
+	}
+	if resp.Token == "" || resp.RefreshToken == "" {
+		t.Error("the login didn't start a session")
+	}
+
+	if len(test.identities.identities) != 1 {
+		t.Fatalf("%d identities linked, want 1", len(test.identities.identities))
+	}
+	identity := test.identities.identities[0]
+	if identity.Username != "janedoe" || identity.Issuer != test.idp.Issuer || identity.Subject != "1234" || identity.Email != "jane@example.com" {
+		t.Errorf("linked %+v", identity)
+	}
+	if len(test.users.users) != 1 || len(test.blogs.blogs) != 0 {
+		t.Error("a user was provisioned although the email belongs to one")
+	}
+	if len(test.sessions.sessions) != 1 || test.sessions.sessions[0].Username != "janedoe" {
+		t.Errorf("sessions = %+v, want one for janedoe", test.sessions.sessions)
+	}
+
+	// the identity is found again the next time, even once the provider has another email for it
+	_, code = test.login(t, jwt.MapClaims{"sub": "1234", "email": "jane@work.example.com", "email_verified": true})
+	if code != http.StatusOK {
+		t.Fatalf("LoginOIDC() = %d, want %d", code, http.StatusOK)
+	}
+	if len(test.identities.identities) != 1 || len(test.sessions.sessions) != 2 || test.sessions.sessions[1].Username != "janedoe" {
+		t.Error("the linked identity didn't log janedoe in")
+	}
+}
+
+func TestLoginOIDCDoesNotLinkUnverifiedEmails(t *testing.T) {
+	tests := []struct {
+		name          string
+		localVerified bool
+		claims        jwt.MapClaims
+		want          int
+	}{
+		{"unverified at the provider", true, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": false}, http.StatusForbidden},
+		{"no email at the provider", true, jwt.MapClaims{"sub": "1234"}, http.StatusForbidden},
+		{"unverified here", false, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": true}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := newOIDCTest(t, true)
+			user := model.User{Username: "janedoe", Email: "jane@example.com", Name: "Jane"}
+			if tt.localVerified {
+				verifiedAt := time.Now()
+				user.EmailVerifiedAt = &verifiedAt
+			}
+			test.addUser(user)
+
+			_, code := test.login(t, tt.claims)
+			if code != tt.want {
+				t.Errorf("LoginOIDC() = %d, want %d", code, tt.want)
+			}
+			if len(test.identities.identities) != 0 || len(test.sessions.sessions) != 0 {
+				t.Error("the identity was linked")
+			}
+		})
+	}
+}
+
+func TestLoginOIDCProvisionsUser(t *testing.T) {
+	test := newOIDCTest(t, true)
+
+	resp, code := test.login(t, jwt.MapClaims{
+		"sub":                "1234",
+		"email":              "jane@example.com",
+		"email_verified":     true,
+		"name":               "Jane Doe",
+		"preferred_username": "Jane.Doe",
+	})
+	if code != http.StatusOK {
+		t.Fatalf("LoginOIDC() = %d, want %d", code, http.StatusOK)
+	}
+	if resp.Token == "" {
+		t.Error("the login didn't start a session")
+	}
+
+	user, ok := test.users.users["jane_doe"]
+	if !ok {
+		t.Fatalf("no user jane_doe among %v", test.users.users)
+	}
+	if user.Email != "jane@example.com" || user.Name != "Jane Doe" || user.EmailVerifiedAt == nil || len(user.Password) != 0 {
+		t.Errorf("provisioned %+v", user)
+	}
+
+	blog, ok := test.blogs.blogs["jane_doe"]
+	if !ok {
+		t.Fatal("the provisioned user has no blog")
+	}
+	if blog.Name != "Jane Doe's blog" {
+		t.Errorf("blog name = %q", blog.Name)
+	}
+
+	if len(test.identities.identities) != 1 || test.identities.identities[0].Username != "jane_doe" {
+		t.Fatalf("identities = %+v, want one for jane_doe", test.identities.identities)
+	}
+
+	// the user, their blog and their identity are created together or not at all
+	tx := test.users.created["jane_doe"]
+	if tx == nil || test.blogs.created["jane_doe"] != tx || test.identities.created[0] != tx {
+		t.Error("the user, the blog and the identity weren't created in one transaction")
+	} else if !tx.committed || tx.rolledBack {
+		t.Error("the transaction wasn't committed")
+	}
+}
+
+func TestLoginOIDCProvisionsFreeUsername(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(test *oidcTest)
+	}{
+		{"taken", func(test *oidcTest) {
+			test.addUser(model.User{Username: "janedoe", Email: "another.jane@example.com"})
+		}},
+		{"someone's old username", func(test *oidcTest) {
+			test.aliases.reserved["janedoe"] = true
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := newOIDCTest(t, true)
+			tt.setup(test)
+
+			_, code := test.login(t, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": true, "preferred_username": "janedoe"})
+			if code != http.StatusOK {
+				t.Fatalf("LoginOIDC() = %d, want %d", code, http.StatusOK)
+			}
+
+			user, err := test.users.FindByEmail(context.Background(), "jane@example.com")
+			if err != nil {
+				t.Fatal("no user was provisioned")
+			}
+			if !strings.HasPrefix(user.Username, "janedoe_") || len(user.Username) != len("janedoe_0000") {
+				t.Errorf("provisioned %s, want janedoe with a suffix", user.Username)
+			}
+			if _, ok := test.blogs.blogs[user.Username]; !ok {
+				t.Error("the provisioned user has no blog")
+			}
+
+			for _, tx := range test.db.txs[:len(test.db.txs)-1] {
+				if !tx.rolledBack {
+					t.Error("a failed attempt wasn't rolled back")
+				}
+			}
+		})
+	}
+}
+
+func TestLoginOIDCWithoutAutoProvision(t *testing.T) {
+	test := newOIDCTest(t, false)
+
+	_, code := test.login(t, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": true})
+	if code != http.StatusForbidden {
+		t.Errorf("LoginOIDC() = %d, want %d", code, http.StatusForbidden)
+	}
+	if len(test.users.users) != 0 || len(test.blogs.blogs) != 0 {
+		t.Error("a user was provisioned")
+	}
+}
+
+func TestLoginOIDCRejectsInvalidIDToken(t *testing.T) {
+	test := newOIDCTest(t, true)
+
+	_, code := test.login(t, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": true, "nonce": "another nonce"})
+	if code != http.StatusUnauthorized {
+		t.Errorf("LoginOIDC() = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if len(test.users.users) != 0 || len(test.sessions.sessions) != 0 {
+		t.Error("a token with the wrong nonce logged someone in")
+	}
+}
+
+func TestLoginOIDCStateUsedOnce(t *testing.T) {
+	test := newOIDCTest(t, true)
+	ctx := context.Background()
+
+	authURL, ucErr := test.uc.StartOIDCLogin(ctx, oidcCallbackURL)
+	if ucErr != nil {
+		t.Fatal(ucErr.String())
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := u.Query().Get("state")
+
+	claims := jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": true}
+	for i, want := range []int{http.StatusOK, http.StatusUnauthorized} {
+		code, err := test.idp.Authorize(authURL, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := http.StatusOK
+		_, ucErr := test.uc.LoginOIDC(ctx, dto.OIDCCallbackRequest{State: state, Code: code}, oidcCallbackURL)
+		if ucErr != nil {
+			got = ucErr.Code
+		}
+		if got != want {
+			t.Errorf("login %d = %d, want %d", i+1, got, want)
+		}
+	}
+}
@@ -5,39 +5,85 @@ import (
 	"errors"
 	"fmt"
 	"goproject/internal/app/delivery/http/auth/dto"
+	lockoutusecase "goproject/internal/app/usecase/lockout"
+	twofactorusecase "goproject/internal/app/usecase/twofactor"
+	verificationusecase "goproject/internal/app/usecase/verification"
 	"goproject/internal/domain/model"
 	"goproject/internal/domain/repository"
 	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/infrastructure/oidc"
 	"goproject/internal/utils"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"gorm.io/gorm"
 )
 
 type AuthUsecase interface {
-	Register(ctx context.Context, data dto.RegisterRequest) *helpers.Error
-	Login(ctx context.Context, data dto.LoginRequest) (*dto.LoginResponse, *helpers.Error)
+	Register(ctx context.Context, data dto.RegisterRequest, verifyURL string) *helpers.Error
+	Login(ctx context.Context, data dto.LoginRequest, ip string) (*dto.LoginResponse, *helpers.Error)
+	Refresh(ctx context.Context, data dto.RefreshRequest) (*dto.LoginResponse, *helpers.Error)
+	Logout(ctx context.Context, sessionID uint) *helpers.Error
+	ForgotPassword(ctx context.Context, data dto.ForgotPasswordRequest) *helpers.Error
+	ResetPassword(ctx context.Context, data dto.ResetPasswordRequest) *helpers.Error
+	LoginTwoFactor(ctx context.Context, data dto.TwoFactorLoginRequest, ip string) (*dto.LoginResponse, *helpers.Error)
+	UnlockAccount(ctx context.Context, data dto.UnlockRequest, ip string) *helpers.Error
+	// StartOIDCLogin returns the identity provider's URL the user has to be sent to, which sends them back to callbackURL.
+	StartOIDCLogin(ctx context.Context, callbackURL string) (string, *helpers.Error)
+	LoginOIDC(ctx context.Context, data dto.OIDCCallbackRequest, callbackURL string) (*dto.LoginResponse, *helpers.Error)
+	// SendMagicLink and RegisterWithMagicLink email a sign-in link to loginURL, which is then given to LoginMagicLink.
+	SendMagicLink(ctx context.Context, data dto.MagicLinkRequest, loginURL string) *helpers.Error
+	RegisterWithMagicLink(ctx context.Context, data dto.MagicLinkRegisterRequest, loginURL string) *helpers.Error
+	LoginMagicLink(ctx context.Context, token string) (*dto.LoginResponse, *helpers.Error)
 }
 
+const (
+	passwordResetTokenLifespan = 30 * time.Minute
+	twoFactorChallengeLifespan = 5 * time.Minute
+	oidcAuthRequestLifespan    = 10 * time.Minute
+	magicLinkLifespan          = 15 * time.Minute
+)
+
 type authUsecaseImpl struct {
-	userRepo repository.UserRepository
-	blogRepo repository.BlogRepository
-	db       *gorm.DB
-	logger   *slog.Logger
+	userRepo        repository.UserRepository
+	blogRepo        repository.BlogRepository
+	sessionRepo     repository.SessionRepository
+	userTokenRepo   repository.UserTokenRepository
+	identityRepo    repository.UserIdentityRepository
+	authRequestRepo repository.OIDCAuthRequestRepository
+	aliasRepo       repository.UsernameAliasRepository
+	verifier        verificationusecase.VerificationUsecase
+	twoFactor       twofactorusecase.TwoFactorUsecase
+	lockout         lockoutusecase.LockoutUsecase
+	oidc            oidc.Provider // nil when single sign-on is off
+	mailer          mail.Mailer
+	db              *gorm.DB
+	logger          *slog.Logger
 }
 
-func NewAuthUsecase(userRepo repository.UserRepository, blogRepo repository.BlogRepository, db *gorm.DB, logger *slog.Logger) AuthUsecase {
+func NewAuthUsecase(userRepo repository.UserRepository, blogRepo repository.BlogRepository, sessionRepo repository.SessionRepository, userTokenRepo repository.UserTokenRepository, identityRepo repository.UserIdentityRepository, authRequestRepo repository.OIDCAuthRequestRepository, aliasRepo repository.UsernameAliasRepository, verifier verificationusecase.VerificationUsecase, twoFactor twofactorusecase.TwoFactorUsecase, lockout lockoutusecase.LockoutUsecase, oidcProvider oidc.Provider, mailer mail.Mailer, db *gorm.DB, logger *slog.Logger) AuthUsecase {
 	return &authUsecaseImpl{
-		userRepo: userRepo,
-		blogRepo: blogRepo,
-		db:       db,
-		logger:   logger,
+		userRepo:        userRepo,
+		blogRepo:        blogRepo,
+		sessionRepo:     sessionRepo,
+		userTokenRepo:   userTokenRepo,
+		identityRepo:    identityRepo,
+		authRequestRepo: authRequestRepo,
+		aliasRepo:       aliasRepo,
+		verifier:        verifier,
+		twoFactor:       twoFactor,
+		lockout:         lockout,
+		oidc:            oidcProvider,
+		mailer:          mailer,
+		db:              db,
+		logger:          logger,
 	}
 }
 
-func (uc *authUsecaseImpl) Register(ctx context.Context, data dto.RegisterRequest) *helpers.Error {
+func (uc *authUsecaseImpl) Register(ctx context.Context, data dto.RegisterRequest, verifyURL string) *helpers.Error {
 	password, err := utils.HashPassword(data.Password)
 	if err != nil {
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
@@ -50,40 +96,68 @@ func (uc *authUsecaseImpl) Register(ctx context.Context, data dto.RegisterReques
 		Username: data.Username,
 	}
 
-	blogData := model.Blog{
-		Name:        fmt.Sprintf("%s's blog", data.Name),
-		Description: fmt.Sprintf("%s's blog description", data.Name),
-		Owner:       data.Username,
-	}
-
 	tx := uc.db.Begin()
 
-	err = uc.userRepo.Create(ctx, userData, tx)
+	err = uc.claimUsername(ctx, userData.Username, tx)
+	if err == nil {
+		err = uc.userRepo.Create(ctx, userData, tx)
+	}
 	if err != nil {
 		tx.Rollback()
-		if errors.Is(err, gorm.ErrDuplicatedKey) {
+		if errors.Is(err, gorm.ErrDuplicatedKey) || errors.Is(err, repository.ErrUsernameReserved) {
 			return helpers.ErrorBuilder(http.StatusConflict, "username/email already used")
 		}
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	err = uc.blogRepo.Create(ctx, blogData, tx)
+	err = uc.blogRepo.Create(ctx, newBlog(userData), tx)
 	if err != nil {
 		tx.Rollback()
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	tx.Commit()
+	err = tx.Commit().Error
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// the account exists either way, a failed email can be sent again through the resend endpoint
+	ucErr := uc.verifier.SendVerificationEmail(ctx, userData, verifyURL)
+	if ucErr != nil {
+		uc.logger.WarnContext(ctx, "verification email not sent after registering", "username", userData.Username, "error", ucErr.String())
+	}
 
 	return nil
 }
 
-func (uc *authUsecaseImpl) Login(ctx context.Context, data dto.LoginRequest) (*dto.LoginResponse, *helpers.Error) {
+// claimUsername keeps the names other users renamed themselves from for a while, so their links can't be taken over.
+func (uc *authUsecaseImpl) claimUsername(ctx context.Context, username string, tx *gorm.DB) error {
+	return uc.aliasRepo.Claim(ctx, username, "", time.Now().Add(-model.UsernameCooldown), tx)
+}
+
+// newBlog is the blog every user starts with.
+func newBlog(user model.User) model.Blog {
+	return model.Blog{
+		Name:        fmt.Sprintf("%s's blog", user.Name),
+		Description: fmt.Sprintf("%s's blog description", user.Name),
+		Owner:       user.Username,
+	}
+}
+
+func (uc *authUsecaseImpl) Login(ctx context.Context, data dto.LoginRequest, ip string) (*dto.LoginResponse, *helpers.Error) {
+	ucErr := uc.lockout.Check(ctx, data.Username, ip)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
 	user, err := uc.userRepo.FindByUsername(ctx, data.Username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// counted like a wrong password, otherwise the lockout would tell which usernames exist
+			uc.lockout.RecordFailure(ctx, data.Username, ip)
 			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "invalid username/password")
 		}
 		uc.logger.ErrorContext(ctx, err.Error())
@@ -92,13 +166,348 @@ func (uc *authUsecaseImpl) Login(ctx context.Context, data dto.LoginRequest) (*d
 
 	err = utils.IsValidPassword(user.Password, data.Password)
 	if err != nil {
+		uc.lockout.RecordFailure(ctx, data.Username, ip)
 		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "invalid username/password")
 	}
 
+	if user.SuspendedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "your account has been suspended")
+	}
+
+	// with 2FA on, the password only gets the user halfway, the session is started by LoginTwoFactor.
+	// failures aren't forgotten yet either, or a known password would allow guessing codes forever
+	if user.TOTPEnabledAt != nil {
+		return uc.startTwoFactorChallenge(ctx, *user)
+	}
+
+	uc.lockout.RecordSuccess(ctx, user.Username)
+
+	return uc.startSession(ctx, *user)
+}
+
+func (uc *authUsecaseImpl) LoginTwoFactor(ctx context.Context, data dto.TwoFactorLoginRequest, ip string) (*dto.LoginResponse, *helpers.Error) {
+	challenge, err := uc.userTokenRepo.FindByHash(ctx, model.TokenPurposeTwoFactorLogin, utils.HashToken(data.ChallengeToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "invalid challenge token")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "challenge token has expired, log in again")
+	}
+
+	ucErr := uc.lockout.Check(ctx, challenge.Username, ip)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	// every attempt uses the challenge up, so codes can't be guessed without going through the password again
+	err = uc.userTokenRepo.Consume(ctx, *challenge)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenUsed) {
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "challenge token has expired, log in again")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	user, err := uc.userRepo.FindByUsername(ctx, challenge.Username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if user.SuspendedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "your account has been suspended")
+	}
+
+	ucErr = uc.twoFactor.VerifyCode(ctx, *user, data.Code)
+	if ucErr != nil {
+		if ucErr.Code == http.StatusUnauthorized {
+			uc.lockout.RecordFailure(ctx, user.Username, ip)
+		}
+		return nil, ucErr
+	}
+
+	uc.lockout.RecordSuccess(ctx, user.Username)
+
+	return uc.startSession(ctx, *user)
+}
+
+func (uc *authUsecaseImpl) Refresh(ctx context.Context, data dto.RefreshRequest) (*dto.LoginResponse, *helpers.Error) {
+	oldToken, err := uc.sessionRepo.FindRefreshTokenByHash(ctx, utils.HashToken(data.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "invalid refresh token")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if oldToken.Session.RevokedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "session has been revoked")
+	}
+
+	// a refresh token that was already rotated is being presented again, meaning it was
+	// most likely stolen. kill the whole token family so neither party can keep using it.
+	if oldToken.UsedAt != nil {
+		return nil, uc.revokeReusedSession(ctx, oldToken.SessionID)
+	}
+
+	if time.Now().After(oldToken.ExpiresAt) {
+		return nil, helpers.ErrorBuilder(http.StatusUnauthorized, "refresh token has expired")
+	}
+
+	// the role may have changed since the last token was issued, so it's read again instead of being copied over
+	user, err := uc.userRepo.FindByUsername(ctx, oldToken.Session.Username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if user.SuspendedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusForbidden, "your account has been suspended")
+	}
+
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	refreshLifespan, err := utils.RefreshTokenLifespan()
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	newToken := model.RefreshToken{
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(refreshLifespan),
+	}
+
+	err = uc.sessionRepo.RotateRefreshToken(ctx, *oldToken, newToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenUsed) {
+			return nil, uc.revokeReusedSession(ctx, oldToken.SessionID)
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return uc.buildLoginResponse(ctx, *user, oldToken.SessionID, refreshToken)
+}
+
+func (uc *authUsecaseImpl) Logout(ctx context.Context, sessionID uint) *helpers.Error {
+	err := uc.sessionRepo.Revoke(ctx, sessionID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *authUsecaseImpl) ForgotPassword(ctx context.Context, data dto.ForgotPasswordRequest) *helpers.Error {
+	// the response is the same whether the email is registered or not, so this can't be used to find out who has an account
+	user, err := uc.userRepo.FindByEmail(ctx, data.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// asking for a new link makes the previous ones useless
+	err = uc.userTokenRepo.InvalidateAll(ctx, user.Username, model.TokenPurposePasswordReset)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userTokenRepo.Create(ctx, model.UserToken{
+		Username:  user.Username,
+		Purpose:   model.TokenPurposePasswordReset,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTokenLifespan),
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Someone asked to reset the password of your account (%s). Use this token to set a new one:\r\n\r\n"+
+			"%s\r\n\r\n"+
+			"It expires in %d minutes and can only be used once. If it wasn't you, you can ignore this email.\r\n",
+			user.Name, user.Username, token, int(passwordResetTokenLifespan.Minutes())),
+	}
+
+	// sent in the background, otherwise the response time would tell whether the email is registered.
+	// the request's context is gone by then, so it can't be used here
+	go func() {
+		err := uc.mailer.Send(context.Background(), msg)
+		if err != nil {
+			uc.logger.Error(err.Error())
+		}
+	}()
+
+	return nil
+}
+
+func (uc *authUsecaseImpl) ResetPassword(ctx context.Context, data dto.ResetPasswordRequest) *helpers.Error {
+	token, err := uc.userTokenRepo.FindByHash(ctx, model.TokenPurposePasswordReset, utils.HashToken(data.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "invalid password reset token")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if token.UsedAt != nil {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "password reset token has already been used")
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "password reset token has expired")
+	}
+
+	password, err := utils.HashPassword(data.NewPassword)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userRepo.ResetPassword(ctx, *token, password)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenUsed) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "password reset token has already been used")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// the failures were made against the old password, the owner shouldn't be kept out with the new one
+	uc.lockout.RecordSuccess(ctx, token.Username)
+
+	return nil
+}
+
+func (uc *authUsecaseImpl) UnlockAccount(ctx context.Context, data dto.UnlockRequest, ip string) *helpers.Error {
+	token, err := uc.userTokenRepo.FindByHash(ctx, model.TokenPurposeAccountUnlock, utils.HashToken(data.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "invalid unlock token")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "unlock token has expired")
+	}
+
+	err = uc.userTokenRepo.Consume(ctx, *token)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenUsed) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "unlock token has expired")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return uc.lockout.Unlock(ctx, token.Username, token.Username, ip)
+}
+
+func (uc *authUsecaseImpl) revokeReusedSession(ctx context.Context, sessionID uint) *helpers.Error {
+	uc.logger.WarnContext(ctx, "refresh token reuse detected", "session_id", sessionID)
+
+	err := uc.sessionRepo.Revoke(ctx, sessionID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return helpers.ErrorBuilder(http.StatusUnauthorized, "refresh token has already been used, session revoked")
+}
+
+func (uc *authUsecaseImpl) startTwoFactorChallenge(ctx context.Context, user model.User) (*dto.LoginResponse, *helpers.Error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userTokenRepo.Create(ctx, model.UserToken{
+		Username:  user.Username,
+		Purpose:   model.TokenPurposeTwoFactorLogin,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(twoFactorChallengeLifespan),
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return &dto.LoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+	}, nil
+}
+
+func (uc *authUsecaseImpl) startSession(ctx context.Context, user model.User) (*dto.LoginResponse, *helpers.Error) {
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	refreshLifespan, err := utils.RefreshTokenLifespan()
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	sessionData := model.Session{
+		Username: user.Username,
+		RefreshTokens: []model.RefreshToken{
+			{
+				TokenHash: utils.HashToken(refreshToken),
+				ExpiresAt: time.Now().Add(refreshLifespan),
+			},
+		},
+	}
+
+	sessionID, err := uc.sessionRepo.Create(ctx, sessionData)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return uc.buildLoginResponse(ctx, user, sessionID, refreshToken)
+}
+
+func (uc *authUsecaseImpl) buildLoginResponse(ctx context.Context, user model.User, sessionID uint, refreshToken string) (*dto.LoginResponse, *helpers.Error) {
 	resp := new(dto.LoginResponse)
 
 	claims := jwt.MapClaims{
 		"username": user.Username,
+		"role":     user.Role,
+		"sid":      sessionID,
 	}
 
 	token, err := utils.GenerateJWT(claims)
@@ -108,6 +517,7 @@ func (uc *authUsecaseImpl) Login(ctx context.Context, data dto.LoginRequest) (*d
 	}
 
 	resp.Token = token
+	resp.RefreshToken = refreshToken
 
 	return resp, nil
 }
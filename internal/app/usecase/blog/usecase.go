@@ -62,11 +62,17 @@ func (uc *blogUsecaseImpl) GetBlogByOwner(ctx context.Context, owner string) (*d
 		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
+	numOfPosts, err := uc.repo.CountPublishedPosts(ctx, blog.ID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
 	data := dto.BlogResponse{
 		Name:        blog.Name,
 		Owner:       blog.Owner,
 		Description: blog.Description,
-		NumOfPosts:  len(blog.Posts),
+		NumOfPosts:  int(numOfPosts),
 	}
 	return &data, nil
 }
@@ -6,11 +6,11 @@ import (
 	"fmt"
 	"goproject/internal/app/delivery/http/comment/dto"
 	"goproject/internal/domain/model"
+	"goproject/internal/domain/policy"
 	"goproject/internal/domain/repository"
 	"goproject/internal/helpers"
 	"log/slog"
 	"net/http"
-	"slices"
 	"strconv"
 
 	"gorm.io/gorm"
@@ -18,12 +18,18 @@ import (
 
 type CommentUsecase interface {
 	CreateComment(ctx context.Context, data dto.CommentRequest, username, blogOwner, postSlug string) (uint, *helpers.Error)
-	GetCommentsByUsername(ctx context.Context, username string) ([]dto.CommentResponse, *helpers.Error)
-	GetCommentsByBlogOwnerAndPostSlug(ctx context.Context, blogOwner, postSlug string) ([]dto.CommentResponse, *helpers.Error)
-	DeleteCommentOnAPosst(ctx context.Context, username, blogOwner, postSlug string, commentID string) *helpers.Error
-	UpdateCommentOnAPost(ctx context.Context, username, blogOwner, postSlug string, commentID string, data dto.CommentRequest) *helpers.Error
+	ReplyToComment(ctx context.Context, data dto.CommentRequest, username, blogOwner, postSlug, commentID string) (uint, *helpers.Error)
+	GetCommentsByUsername(ctx context.Context, username string, page repository.PageRequest) ([]dto.CommentResponse, *helpers.Pagination, *helpers.Error)
+	GetCommentsByBlogOwnerAndPostSlug(ctx context.Context, blogOwner, postSlug, maxDepth string, page repository.PageRequest) ([]dto.CommentResponse, *helpers.Pagination, *helpers.Error)
+	DeleteCommentOnAPosst(ctx context.Context, actor policy.Actor, blogOwner, postSlug string, commentID string) *helpers.Error
+	UpdateCommentOnAPost(ctx context.Context, actor policy.Actor, blogOwner, postSlug string, commentID string, data dto.CommentRequest) *helpers.Error
 }
 
+const (
+	defaultCommentDepth = 3
+	maxCommentDepth     = 10
+)
+
 type commentUsecaseImpl struct {
 	commentRepo repository.CommentRepository
 	postRepo    repository.PostRepository
@@ -39,7 +45,7 @@ func NewCommentUsecase(commentRepo repository.CommentRepository, postRepo reposi
 }
 
 func (uc *commentUsecaseImpl) CreateComment(ctx context.Context, data dto.CommentRequest, username, blogOwner, postSlug string) (uint, *helpers.Error) {
-	post, err := uc.postRepo.FindBySlugAndOwner(ctx, postSlug, blogOwner)
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return 0, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
@@ -49,7 +55,7 @@ func (uc *commentUsecaseImpl) CreateComment(ctx context.Context, data dto.Commen
 	}
 
 	commentData := model.Comment{
-		Commenter: username,
+		Commenter: &username,
 		PostID:    post.ID,
 		Content:   data.Comment,
 	}
@@ -62,64 +68,167 @@ func (uc *commentUsecaseImpl) CreateComment(ctx context.Context, data dto.Commen
 	return id, nil
 }
 
-func (uc *commentUsecaseImpl) GetCommentsByUsername(ctx context.Context, username string) ([]dto.CommentResponse, *helpers.Error) {
+func (uc *commentUsecaseImpl) ReplyToComment(ctx context.Context, data dto.CommentRequest, username, blogOwner, postSlug, commentID string) (uint, *helpers.Error) {
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return 0, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	parent, ucErr := uc.findCommentOnPost(ctx, *post, commentID)
+	if ucErr != nil {
+		return 0, ucErr
+	}
+
+	if parent.HiddenAt != nil {
+		return 0, helpers.ErrorBuilder(http.StatusForbidden, "this comment has been hidden by a moderator and can't be replied to")
+	}
+
+	commentData := model.Comment{
+		Commenter: &username,
+		PostID:    post.ID,
+		ParentID:  &parent.ID,
+		Content:   data.Comment,
+	}
+
+	id, err := uc.commentRepo.Create(ctx, commentData)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return 0, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	return id, nil
+}
+
+func (uc *commentUsecaseImpl) GetCommentsByUsername(ctx context.Context, username string, page repository.PageRequest) ([]dto.CommentResponse, *helpers.Pagination, *helpers.Error) {
 	commentsData := make([]dto.CommentResponse, 0)
 
-	comments, err := uc.commentRepo.FindCommentByUsername(ctx, username)
+	comments, err := uc.commentRepo.FindCommentByUsername(ctx, username, page)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
+	comments, pagination := helpers.BuildPage(comments, page, commentCursor)
+
 	for _, comment := range comments {
 		commentsData = append(commentsData, dto.CommentResponse{
 			ID:        comment.ID,
+			ParentID:  comment.ParentID,
 			PostURL:   fmt.Sprintf("blog/%s/posts/%s", comment.Post.Blog.Owner, comment.Post.Slug),
 			Comment:   comment.Content,
+			Hidden:    comment.HiddenAt != nil,
 			CreatedAt: comment.CreatedAt,
 			UpdatedAt: comment.UpdatedAt,
 		})
 	}
 
-	return commentsData, nil
+	return commentsData, pagination, nil
 }
 
-func (uc *commentUsecaseImpl) GetCommentsByBlogOwnerAndPostSlug(ctx context.Context, blogOwner, postSlug string) ([]dto.CommentResponse, *helpers.Error) {
+func (uc *commentUsecaseImpl) GetCommentsByBlogOwnerAndPostSlug(ctx context.Context, blogOwner, postSlug, maxDepth string, page repository.PageRequest) ([]dto.CommentResponse, *helpers.Pagination, *helpers.Error) {
 	commentsData := make([]dto.CommentResponse, 0)
 
-	post, err := uc.postRepo.FindBySlugAndOwner(ctx, postSlug, blogOwner)
+	depth := defaultCommentDepth
+	if maxDepth != "" {
+		n, err := strconv.Atoi(maxDepth)
+		if err != nil || n < 0 || n > maxCommentDepth {
+			return nil, nil, helpers.ErrorBuilder(http.StatusBadRequest, fmt.Sprintf("max_depth must be an integer between 0 and %d", maxCommentDepth))
+		}
+		depth = n
+	}
+
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
+			return nil, nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
 		}
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	comments, err := uc.commentRepo.FindCommentByPostID(ctx, post.ID)
+	// only top-level comments are paginated, each one comes with its replies up to the requested depth
+	roots, err := uc.commentRepo.FindRootCommentsByPostID(ctx, post.ID, page)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	for _, comment := range comments {
-		commentsData = append(commentsData, dto.CommentResponse{
-			ID:        comment.ID,
-			Commenter: comment.Commenter,
-			Comment:   comment.Content,
-			CreatedAt: comment.CreatedAt,
-			UpdatedAt: comment.UpdatedAt,
-		})
+	roots, pagination := helpers.BuildPage(roots, page, commentCursor)
+
+	ids := make([]uint, 0, len(roots))
+	for _, root := range roots {
+		ids = append(ids, root.ID)
+	}
+
+	replies, err := uc.commentRepo.FindRepliesByRootIDs(ctx, ids, depth)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	repliesByParent := make(map[uint][]model.Comment)
+	for _, reply := range replies {
+		ids = append(ids, reply.ID)
+		repliesByParent[*reply.ParentID] = append(repliesByParent[*reply.ParentID], reply)
+	}
+
+	numOfReplies, err := uc.commentRepo.CountRepliesByParentIDs(ctx, ids)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	var buildThread func(comment model.Comment, level int) dto.CommentResponse
+	buildThread = func(comment model.Comment, level int) dto.CommentResponse {
+		count := numOfReplies[comment.ID]
+		commentData := dto.CommentResponse{
+			ID:           comment.ID,
+			ParentID:     comment.ParentID,
+			Comment:      comment.Content,
+			CreatedAt:    comment.CreatedAt,
+			UpdatedAt:    comment.UpdatedAt,
+			NumOfReplies: &count,
+		}
+
+		if comment.Commenter != nil {
+			commentData.Commenter = *comment.Commenter
+		}
+
+		if comment.DeletedAt != nil {
+			commentData.Commenter = ""
+			commentData.Comment = "[deleted]"
+			commentData.Deleted = true
+		} else if comment.HiddenAt != nil {
+			commentData.Comment = "[hidden]"
+			commentData.Hidden = true
+		}
+
+		if level < depth {
+			threadReplies := make([]dto.CommentResponse, 0, len(repliesByParent[comment.ID]))
+			for _, reply := range repliesByParent[comment.ID] {
+				threadReplies = append(threadReplies, buildThread(reply, level+1))
+			}
+			commentData.Replies = &threadReplies
+		}
+
+		return commentData
 	}
 
-	return commentsData, nil
+	for _, root := range roots {
+		commentsData = append(commentsData, buildThread(root, 0))
+	}
+
+	return commentsData, pagination, nil
 }
 
-func (uc *commentUsecaseImpl) DeleteCommentOnAPosst(ctx context.Context, username, blogOwner, postSlug string, commentID string) *helpers.Error {
-	// blog owner can delete any users comment on their post
+func (uc *commentUsecaseImpl) DeleteCommentOnAPosst(ctx context.Context, actor policy.Actor, blogOwner, postSlug string, commentID string) *helpers.Error {
+	// blog owner can delete any users comment on their post, and admins can delete any comment anywhere
 	// user (non blog owner) can ony delete their own comments on someone else's blog post
 
-	post, err := uc.postRepo.FindBySlugAndOwner(ctx, postSlug, blogOwner)
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
@@ -128,30 +237,16 @@ func (uc *commentUsecaseImpl) DeleteCommentOnAPosst(ctx context.Context, usernam
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	comments, err := uc.commentRepo.FindCommentByPostID(ctx, post.ID)
-	if err != nil {
-		uc.logger.ErrorContext(ctx, err.Error())
-		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
-	}
-
-	id, err := strconv.ParseUint(commentID, 10, 64)
-	if err != nil {
-		return helpers.ErrorBuilder(http.StatusBadRequest, "comment id must be a positive integer")
+	comment, ucErr := uc.findCommentOnPost(ctx, *post, commentID)
+	if ucErr != nil {
+		return ucErr
 	}
 
-	commentIdx := slices.IndexFunc(comments, func(c model.Comment) bool {
-		return c.ID == uint(id)
-	})
-
-	if commentIdx == -1 {
-		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("comment with id %s not found on this post", commentID))
-	}
-
-	if comments[commentIdx].Commenter != username && username != blogOwner {
+	if !policy.CanDeleteComment(actor, *comment, blogOwner) {
 		return helpers.ErrorBuilder(http.StatusUnauthorized, "you're not allowed to delete this comment")
 	}
 
-	err = uc.commentRepo.Delete(ctx, comments[commentIdx])
+	err = uc.commentRepo.Delete(ctx, *comment)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
@@ -160,10 +255,10 @@ func (uc *commentUsecaseImpl) DeleteCommentOnAPosst(ctx context.Context, usernam
 	return nil
 }
 
-func (uc *commentUsecaseImpl) UpdateCommentOnAPost(ctx context.Context, username, blogOwner, postSlug string, commentID string, data dto.CommentRequest) *helpers.Error {
+func (uc *commentUsecaseImpl) UpdateCommentOnAPost(ctx context.Context, actor policy.Actor, blogOwner, postSlug string, commentID string, data dto.CommentRequest) *helpers.Error {
 	// the only person able to edit a comment is the commenter
 
-	post, err := uc.postRepo.FindBySlugAndOwner(ctx, postSlug, blogOwner)
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
@@ -172,36 +267,45 @@ func (uc *commentUsecaseImpl) UpdateCommentOnAPost(ctx context.Context, username
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	comments, err := uc.commentRepo.FindCommentByPostID(ctx, post.ID)
+	comment, ucErr := uc.findCommentOnPost(ctx, *post, commentID)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	if !policy.CanEditComment(actor, *comment) {
+		return helpers.ErrorBuilder(http.StatusUnauthorized, "you're not allowed to modify this comment")
+	}
+
+	comment.Content = data.Comment
+
+	err = uc.commentRepo.Update(ctx, *comment)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
+	return nil
+}
+
+func (uc *commentUsecaseImpl) findCommentOnPost(ctx context.Context, post model.Post, commentID string) (*model.Comment, *helpers.Error) {
 	id, err := strconv.ParseUint(commentID, 10, 64)
 	if err != nil {
-		return helpers.ErrorBuilder(http.StatusBadRequest, "comment id must be a positive integer")
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "comment id must be a positive integer")
 	}
 
-	commentIdx := slices.IndexFunc(comments, func(c model.Comment) bool {
-		return c.ID == uint(id)
-	})
-
-	if commentIdx == -1 {
-		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("comment with id %s not found on this post", commentID))
+	comment, err := uc.commentRepo.FindCommentByID(ctx, uint(id))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	if comments[commentIdx].Commenter != username {
-		return helpers.ErrorBuilder(http.StatusUnauthorized, "you're not allowed to modify this comment")
+	if err != nil || comment.PostID != post.ID || comment.DeletedAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("comment with id %s not found on this post", commentID))
 	}
 
-	comments[commentIdx].Content = data.Comment
-
-	err = uc.commentRepo.Update(ctx, comments[commentIdx])
-	if err != nil {
-		uc.logger.ErrorContext(ctx, err.Error())
-		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
-	}
+	return comment, nil
+}
 
-	return nil
+func commentCursor(comment model.Comment) repository.Cursor {
+	return repository.Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID}
 }
@@ -0,0 +1,157 @@
+package feedusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/feed/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// feedSize is how many of the newest posts end up in a feed.
+const feedSize = 20
+
+type FeedUsecase interface {
+	GetBlogFeed(ctx context.Context, username, tag string) (*dto.Feed, *helpers.Error)
+	GetTagFeed(ctx context.Context, tag string) (*dto.Feed, *helpers.Error)
+	GetListFeed(ctx context.Context, username, listSlug string) (*dto.Feed, *helpers.Error)
+}
+
+type feedUsecaseImpl struct {
+	blogRepo repository.BlogRepository
+	postRepo repository.PostRepository
+	tagRepo  repository.TagRepository
+	listRepo repository.ListRepository
+	logger   *slog.Logger
+}
+
+func NewFeedUsecase(blogRepo repository.BlogRepository, postRepo repository.PostRepository, tagRepo repository.TagRepository, listRepo repository.ListRepository, logger *slog.Logger) FeedUsecase {
+	return &feedUsecaseImpl{
+		blogRepo: blogRepo,
+		postRepo: postRepo,
+		tagRepo:  tagRepo,
+		listRepo: listRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *feedUsecaseImpl) GetBlogFeed(ctx context.Context, username, tag string) (*dto.Feed, *helpers.Error) {
+	blog, err := uc.blogRepo.FindByOwner(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s's blog not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	tag = helpers.SlugifyTag(tag)
+	posts, err := uc.postRepo.FindPublishedByBlogID(ctx, blog.ID, tag, repository.PageRequest{Limit: feedSize})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	feed := &dto.Feed{
+		Title:       blog.Name,
+		Description: blog.Description,
+		Path:        fmt.Sprintf("blog/%s/posts", blog.Owner),
+		Author:      blog.User.Name,
+	}
+	if tag != "" {
+		feed.Title = fmt.Sprintf("%s #%s", blog.Name, tag)
+		feed.Path += "?tag=" + url.QueryEscape(tag)
+	}
+	setFeedItems(feed, posts, blog.UpdatedAt)
+
+	return feed, nil
+}
+
+func (uc *feedUsecaseImpl) GetTagFeed(ctx context.Context, tag string) (*dto.Feed, *helpers.Error) {
+	tagData, err := uc.tagRepo.FindBySlug(ctx, helpers.SlugifyTag(tag))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("tag %s not found", tag))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	posts, err := uc.postRepo.FindPublishedByTagID(ctx, tagData.ID, repository.PageRequest{Limit: feedSize})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	feed := &dto.Feed{
+		Title:       fmt.Sprintf("Posts tagged #%s", tagData.Name),
+		Description: fmt.Sprintf("The newest posts tagged #%s from every blog", tagData.Name),
+		Path:        fmt.Sprintf("tags/%s/posts", url.PathEscape(tagData.Slug)),
+	}
+	setFeedItems(feed, posts, tagData.CreatedAt)
+
+	return feed, nil
+}
+
+func (uc *feedUsecaseImpl) GetListFeed(ctx context.Context, username, listSlug string) (*dto.Feed, *helpers.Error) {
+	list, err := uc.listRepo.FindPostsInAListByListSlug(ctx, username, listSlug, repository.PageRequest{Limit: feedSize})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// private lists don't have a feed, and shouldn't be told apart from lists that don't exist
+	if err != nil || !list.IsPublic {
+		return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("list %s not found", listSlug))
+	}
+
+	feed := &dto.Feed{
+		Title:       list.Name,
+		Description: list.Description,
+	}
+	setFeedItems(feed, list.Posts, list.UpdatedAt)
+
+	return feed, nil
+}
+
+// setFeedItems fills the feed with the posts, fetched with one extra row like any other page.
+// The feed is as fresh as its most recently updated post, or the given time when that's more recent, e.g. the blog
+// was renamed.
+func setFeedItems(feed *dto.Feed, posts []model.Post, updated time.Time) {
+	if len(posts) > feedSize {
+		posts = posts[:feedSize]
+	}
+
+	feed.Updated = updated
+	feed.Items = make([]dto.FeedItem, 0, len(posts))
+	for _, post := range posts {
+		item := dto.FeedItem{
+			Title:       post.Title,
+			Summary:     post.Excerpt,
+			ContentHTML: post.ContentHTML,
+			Path:        fmt.Sprintf("blog/%s/posts/%s", post.Blog.User.Username, post.Slug),
+			Author:      post.Blog.User.Name,
+			PublishedAt: post.CreatedAt,
+			UpdatedAt:   post.UpdatedAt,
+		}
+		if post.PublishedAt != nil {
+			item.PublishedAt = *post.PublishedAt
+		}
+		for _, tag := range post.Tags {
+			item.Tags = append(item.Tags, tag.Slug)
+		}
+
+		if post.UpdatedAt.After(feed.Updated) {
+			feed.Updated = post.UpdatedAt
+		}
+		feed.Items = append(feed.Items, item)
+	}
+}
@@ -0,0 +1,135 @@
+package followusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/follow/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"log/slog"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type FollowUsecase interface {
+	Follow(ctx context.Context, follower, followee string) *helpers.Error
+	Unfollow(ctx context.Context, follower, followee string) *helpers.Error
+	GetFollowers(ctx context.Context, username, viewer string, page repository.PageRequest) ([]dto.FollowResponse, *helpers.Pagination, *helpers.Error)
+	GetFollowing(ctx context.Context, username, viewer string, page repository.PageRequest) ([]dto.FollowResponse, *helpers.Pagination, *helpers.Error)
+}
+
+type followUsecaseImpl struct {
+	followRepo repository.FollowRepository
+	userRepo   repository.UserRepository
+	logger     *slog.Logger
+}
+
+func NewFollowUsecase(followRepo repository.FollowRepository, userRepo repository.UserRepository, logger *slog.Logger) FollowUsecase {
+	return &followUsecaseImpl{
+		followRepo: followRepo,
+		userRepo:   userRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *followUsecaseImpl) Follow(ctx context.Context, follower, followee string) *helpers.Error {
+	if follower == followee {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "you can't follow yourself")
+	}
+
+	_, ucErr := uc.findUser(ctx, followee)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	err := uc.followRepo.Create(ctx, model.Follow{
+		Follower: follower,
+		Followee: followee,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return helpers.ErrorBuilder(http.StatusConflict, fmt.Sprintf("you're already following %s", followee))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *followUsecaseImpl) Unfollow(ctx context.Context, follower, followee string) *helpers.Error {
+	n, err := uc.followRepo.Delete(ctx, follower, followee)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if n == 0 {
+		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("you're not following %s", followee))
+	}
+
+	return nil
+}
+
+func (uc *followUsecaseImpl) GetFollowers(ctx context.Context, username, viewer string, page repository.PageRequest) ([]dto.FollowResponse, *helpers.Pagination, *helpers.Error) {
+	return uc.getFollows(ctx, username, viewer, page, uc.followRepo.FindFollowers, func(follow model.Follow) model.User {
+		return follow.FollowerUser
+	})
+}
+
+func (uc *followUsecaseImpl) GetFollowing(ctx context.Context, username, viewer string, page repository.PageRequest) ([]dto.FollowResponse, *helpers.Pagination, *helpers.Error) {
+	return uc.getFollows(ctx, username, viewer, page, uc.followRepo.FindFollowing, func(follow model.Follow) model.User {
+		return follow.FolloweeUser
+	})
+}
+
+// getFollows lists one side of username's follows, userOf picks the user on the other side. Users can keep their
+// follows to themselves, the lists are then hidden from everyone but them, the same as the counts on their profile.
+// viewer is the user asking, empty when they aren't logged in.
+func (uc *followUsecaseImpl) getFollows(ctx context.Context, username, viewer string, page repository.PageRequest, find func(ctx context.Context, username string, page repository.PageRequest) ([]model.Follow, error), userOf func(model.Follow) model.User) ([]dto.FollowResponse, *helpers.Pagination, *helpers.Error) {
+	followsData := make([]dto.FollowResponse, 0)
+
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return nil, nil, ucErr
+	}
+
+	if !user.ShowFollows && viewer != user.Username {
+		return nil, nil, helpers.ErrorBuilder(http.StatusForbidden, fmt.Sprintf("%s keeps their follows private", username))
+	}
+
+	follows, err := find(ctx, username, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	follows, pagination := helpers.BuildPage(follows, page, func(follow model.Follow) repository.Cursor {
+		return repository.Cursor{CreatedAt: follow.CreatedAt, ID: follow.ID}
+	})
+	for _, follow := range follows {
+		user := userOf(follow)
+		followsData = append(followsData, dto.FollowResponse{
+			Username:   user.Username,
+			Name:       user.Name,
+			FollowedAt: follow.CreatedAt,
+		})
+	}
+
+	return followsData, pagination, nil
+}
+
+func (uc *followUsecaseImpl) findUser(ctx context.Context, username string) (*model.User, *helpers.Error) {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	return user, nil
+}
@@ -11,7 +11,6 @@ import (
 	"goproject/internal/helpers"
 	"log/slog"
 	"net/http"
-	"slices"
 
 	"gorm.io/gorm"
 )
@@ -19,8 +18,8 @@ import (
 type ListUsecase interface {
 	CreateNewList(ctx context.Context, data dto.ListRequest, username string) (*dto.CreateListResponse, *helpers.Error)
 	AddPostToMyList(ctx context.Context, listSlug, username, blogOwner, postSlug string) *helpers.Error
-	GetPostsInAListBySlug(ctx context.Context, listSlug, username string) (*dto.ListResponse, *helpers.Error)
-	GetMyLists(ctx context.Context, username string) ([]dto.ListResponse, *helpers.Error)
+	GetPostsInAListBySlug(ctx context.Context, listSlug, username string, page repository.PageRequest) (*dto.ListResponse, *helpers.Pagination, *helpers.Error)
+	GetMyLists(ctx context.Context, username string, page repository.PageRequest) ([]dto.ListResponse, *helpers.Pagination, *helpers.Error)
 	UpdateListInformation(ctx context.Context, data dto.ListRequest, username, listSlug string) *helpers.Error
 	RemovePostFromList(ctx context.Context, username, slug, listSlug string) *helpers.Error
 	DeleteListBySlug(ctx context.Context, username, listSlug string) *helpers.Error
@@ -47,6 +46,7 @@ func (uc *listUsecaseImpl) CreateNewList(ctx context.Context, data dto.ListReque
 		Name:        data.Name,
 		Slug:        helpers.GenerateSlug(data.Name),
 		Description: data.Description,
+		IsPublic:    data.IsPublic != nil && *data.IsPublic,
 		Owner:       username,
 	}
 
@@ -62,7 +62,7 @@ func (uc *listUsecaseImpl) CreateNewList(ctx context.Context, data dto.ListReque
 }
 
 func (uc *listUsecaseImpl) AddPostToMyList(ctx context.Context, listSlug, username, blogOwner, postSlug string) *helpers.Error {
-	post, err := uc.postRepo.FindBySlugAndOwner(ctx, postSlug, blogOwner)
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
@@ -92,57 +92,70 @@ func (uc *listUsecaseImpl) AddPostToMyList(ctx context.Context, listSlug, userna
 	return nil
 }
 
-func (uc *listUsecaseImpl) GetPostsInAListBySlug(ctx context.Context, listSlug, username string) (*dto.ListResponse, *helpers.Error) {
+func (uc *listUsecaseImpl) GetPostsInAListBySlug(ctx context.Context, listSlug, username string, page repository.PageRequest) (*dto.ListResponse, *helpers.Pagination, *helpers.Error) {
 	listData := new(dto.ListResponse)
 	listData.Posts = &[]postDto.PostResponse{}
 
-	list, err := uc.listRepo.FindPostsInAListByListSlug(ctx, username, listSlug)
+	list, err := uc.listRepo.FindPostsInAListByListSlug(ctx, username, listSlug, page)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("list %s not found", listSlug))
+			return nil, nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("list %s not found", listSlug))
 		}
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
 	listData.Slug = list.Slug
 	listData.Name = list.Name
 	listData.Description = list.Description
+	listData.IsPublic = list.IsPublic
 
-	for _, post := range list.Posts {
-		*listData.Posts = append(*listData.Posts, postDto.PostResponse{
-			Title:     post.Title,
-			Content:   post.Content,
-			Slug:      post.Slug,
-			Author:    post.Blog.User.Name,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
-		})
+	posts, pagination := helpers.BuildPage(list.Posts, page, func(post model.Post) repository.Cursor {
+		return repository.Cursor{PublishedAt: post.PublishedAt, ID: post.ID}
+	})
+	for _, post := range posts {
+		*listData.Posts = append(*listData.Posts, postDto.NewPostResponse(post))
 	}
 
-	return listData, nil
+	return listData, pagination, nil
 }
 
-func (uc *listUsecaseImpl) GetMyLists(ctx context.Context, username string) ([]dto.ListResponse, *helpers.Error) {
+func (uc *listUsecaseImpl) GetMyLists(ctx context.Context, username string, page repository.PageRequest) ([]dto.ListResponse, *helpers.Pagination, *helpers.Error) {
 	listsData := make([]dto.ListResponse, 0)
 
-	lists, err := uc.listRepo.FindListsByOwner(ctx, username)
+	lists, err := uc.listRepo.FindListsByOwner(ctx, username, page)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	lists, pagination := helpers.BuildPage(lists, page, func(list model.List) repository.Cursor {
+		return repository.Cursor{CreatedAt: list.CreatedAt, ID: list.ID}
+	})
+
+	ids := make([]uint, 0, len(lists))
+	for _, list := range lists {
+		ids = append(ids, list.ID)
+	}
+
+	counts, err := uc.listRepo.CountPublishedPostsByListIDs(ctx, ids)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
 	for _, list := range lists {
-		numOfPosts := len(list.Posts)
+		numOfPosts := int(counts[list.ID])
 		listsData = append(listsData, dto.ListResponse{
 			Slug:        list.Slug,
 			Name:        list.Name,
 			Description: list.Description,
+			IsPublic:    list.IsPublic,
 			NumOfPosts:  &numOfPosts,
 		})
 	}
 
-	return listsData, nil
+	return listsData, pagination, nil
 }
 
 func (uc *listUsecaseImpl) UpdateListInformation(ctx context.Context, data dto.ListRequest, username, listSlug string) *helpers.Error {
@@ -157,6 +170,9 @@ func (uc *listUsecaseImpl) UpdateListInformation(ctx context.Context, data dto.L
 
 	list.Name = data.Name
 	list.Description = data.Description
+	if data.IsPublic != nil {
+		list.IsPublic = *data.IsPublic
+	}
 
 	err = uc.listRepo.Update(ctx, *list)
 	if err != nil {
@@ -167,7 +183,7 @@ func (uc *listUsecaseImpl) UpdateListInformation(ctx context.Context, data dto.L
 }
 
 func (uc *listUsecaseImpl) RemovePostFromList(ctx context.Context, username, postSlug, listSlug string) *helpers.Error {
-	list, err := uc.listRepo.FindPostsInAListByListSlug(ctx, username, listSlug)
+	list, err := uc.listRepo.FindListByOwnerAndListSlug(ctx, username, listSlug)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("list %s not found", listSlug))
@@ -176,16 +192,16 @@ func (uc *listUsecaseImpl) RemovePostFromList(ctx context.Context, username, pos
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	postIdx := slices.IndexFunc(list.Posts, func(post model.Post) bool {
-		return post.Slug == postSlug
-	})
-	if postIdx == -1 {
-		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s not found on this list", postSlug))
+	post, err := uc.listRepo.FindPostInListBySlug(ctx, list.ID, postSlug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s not found on this list", postSlug))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	post := list.Posts[postIdx]
-
-	err = uc.listRepo.RemovePostFromList(ctx, post, *list)
+	err = uc.listRepo.RemovePostFromList(ctx, *post, *list)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
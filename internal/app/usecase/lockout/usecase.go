@@ -0,0 +1,229 @@
+package lockoutusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/utils"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LockoutUsecase slows down and then locks out usernames and IP addresses that keep failing to log in.
+type LockoutUsecase interface {
+	// Check refuses the attempt when the username or the IP is locked out or still has to wait.
+	Check(ctx context.Context, username, ip string) *helpers.Error
+	RecordFailure(ctx context.Context, username, ip string)
+	// RecordSuccess forgets the username's failures, the IP's are kept so one valid account can't be used to reset them.
+	RecordSuccess(ctx context.Context, username string)
+	Unlock(ctx context.Context, username, actor, ip string) *helpers.Error
+	PruneAttempts(ctx context.Context) error
+}
+
+const (
+	// failures older than this are forgotten
+	failureWindow = time.Hour
+	// from this many failures on, every attempt has to wait twice as long as the previous one
+	backoffAfter = 3
+	backoffBase  = time.Second
+	maxBackoff   = time.Minute
+	// usernames are locked sooner, an IP may be shared by a lot of users
+	usernameLockAfter = 10
+	ipLockAfter       = 50
+	lockoutDuration   = 30 * time.Minute
+	// someone who keeps locking a user out shouldn't be able to flood their inbox as well
+	unlockEmailLimit  = 3
+	unlockEmailWindow = 24 * time.Hour
+)
+
+type lockoutUsecaseImpl struct {
+	attemptRepo   repository.LoginAttemptRepository
+	auditRepo     repository.AuditLogRepository
+	userRepo      repository.UserRepository
+	userTokenRepo repository.UserTokenRepository
+	mailer        mail.Mailer
+	logger        *slog.Logger
+}
+
+func NewLockoutUsecase(attemptRepo repository.LoginAttemptRepository, auditRepo repository.AuditLogRepository, userRepo repository.UserRepository, userTokenRepo repository.UserTokenRepository, mailer mail.Mailer, logger *slog.Logger) LockoutUsecase {
+	return &lockoutUsecaseImpl{
+		attemptRepo:   attemptRepo,
+		auditRepo:     auditRepo,
+		userRepo:      userRepo,
+		userTokenRepo: userTokenRepo,
+		mailer:        mailer,
+		logger:        logger,
+	}
+}
+
+func (uc *lockoutUsecaseImpl) Check(ctx context.Context, username, ip string) *helpers.Error {
+	now := time.Now()
+
+	for _, key := range []string{model.UsernameAttemptKey(username), model.IPAttemptKey(ip)} {
+		attempt, err := uc.attemptRepo.Find(ctx, key)
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		if attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+			return helpers.ErrorBuilder(http.StatusTooManyRequests, fmt.Sprintf("too many failed login attempts, locked until %s", attempt.LockedUntil.Format(time.RFC3339)))
+		}
+
+		if retryAt := attempt.LastFailedAt.Add(backoff(attempt.Failures)); now.Before(retryAt) {
+			retryIn := max(retryAt.Sub(now).Round(time.Second), time.Second)
+			return helpers.ErrorBuilder(http.StatusTooManyRequests, fmt.Sprintf("too many failed login attempts, try again in %s", retryIn))
+		}
+	}
+
+	return nil
+}
+
+func backoff(failures int) time.Duration {
+	if failures < backoffAfter {
+		return 0
+	}
+	// the shift is capped, a big enough failure count would overflow it
+	wait := backoffBase << min(failures-backoffAfter, 16)
+	return min(wait, maxBackoff)
+}
+
+func (uc *lockoutUsecaseImpl) RecordFailure(ctx context.Context, username, ip string) {
+	uc.recordFailure(ctx, model.UsernameAttemptKey(username), usernameLockAfter, username, ip)
+	uc.recordFailure(ctx, model.IPAttemptKey(ip), ipLockAfter, "", ip)
+}
+
+func (uc *lockoutUsecaseImpl) recordFailure(ctx context.Context, key string, lockAfter int, username, ip string) {
+	now := time.Now()
+
+	attempt, err := uc.attemptRepo.RecordFailure(ctx, key, now, failureWindow)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	if attempt.Failures < lockAfter {
+		return
+	}
+
+	err = uc.attemptRepo.Lock(ctx, key, now.Add(lockoutDuration))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	uc.audit(ctx, model.AuditLog{
+		Action:   model.AuditActionLoginLockout,
+		Username: username,
+		IP:       ip,
+	})
+
+	if username != "" {
+		uc.sendUnlockEmail(ctx, username)
+	}
+}
+
+// sendUnlockEmail lets the owner get back in before the lockout ends. Nothing is sent for usernames that don't exist,
+// nor once unlockEmailLimit emails were sent within unlockEmailWindow, the lockout still ends on its own.
+func (uc *lockoutUsecaseImpl) sendUnlockEmail(ctx context.Context, username string) {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			uc.logger.ErrorContext(ctx, err.Error())
+		}
+		return
+	}
+
+	sent, err := uc.userTokenRepo.CountCreatedSince(ctx, username, model.TokenPurposeAccountUnlock, time.Now().Add(-unlockEmailWindow))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return
+	}
+	if sent >= unlockEmailLimit {
+		uc.logger.WarnContext(ctx, "locked out too many times, not sending another unlock email", "username", username)
+		return
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	err = uc.userTokenRepo.InvalidateAll(ctx, username, model.TokenPurposeAccountUnlock)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	err = uc.userTokenRepo.Create(ctx, model.UserToken{
+		Username:  username,
+		Purpose:   model.TokenPurposeAccountUnlock,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(lockoutDuration),
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Your account has been locked",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Logging in to your account (%s) failed too many times, so it's locked for %d minutes. If it was you, use this token to unlock it right away:\r\n\r\n"+
+			"%s\r\n\r\n"+
+			"If it wasn't you, someone may be trying to guess your password. Consider changing it and turning on two-factor authentication.\r\n",
+			user.Name, user.Username, int(lockoutDuration.Minutes()), token),
+	}
+
+	go func() {
+		err := uc.mailer.Send(context.Background(), msg)
+		if err != nil {
+			uc.logger.Error(err.Error())
+		}
+	}()
+}
+
+func (uc *lockoutUsecaseImpl) RecordSuccess(ctx context.Context, username string) {
+	err := uc.attemptRepo.Reset(ctx, model.UsernameAttemptKey(username))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+	}
+}
+
+func (uc *lockoutUsecaseImpl) Unlock(ctx context.Context, username, actor, ip string) *helpers.Error {
+	err := uc.attemptRepo.Reset(ctx, model.UsernameAttemptKey(username))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	uc.audit(ctx, model.AuditLog{
+		Action:   model.AuditActionLoginUnlock,
+		Username: username,
+		Actor:    actor,
+		IP:       ip,
+	})
+
+	return nil
+}
+
+func (uc *lockoutUsecaseImpl) PruneAttempts(ctx context.Context) error {
+	return uc.attemptRepo.DeleteStale(ctx, time.Now().Add(-failureWindow))
+}
+
+// audit failing isn't a reason to fail what's being audited, it's only logged.
+func (uc *lockoutUsecaseImpl) audit(ctx context.Context, data model.AuditLog) {
+	err := uc.auditRepo.Create(ctx, data)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+	}
+}
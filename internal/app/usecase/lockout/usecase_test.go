@@ -0,0 +1,27 @@
+package lockoutusecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		0:       0,
+		1:       0,
+		2:       0,
+		3:       time.Second,
+		4:       2 * time.Second,
+		5:       4 * time.Second,
+		8:       32 * time.Second,
+		9:       time.Minute,
+		100:     time.Minute,
+		1 << 30: time.Minute,
+	}
+
+	for failures, want := range tests {
+		if got := backoff(failures); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", failures, got, want)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package mediausecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/media/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/storage"
+	"goproject/internal/utils"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+type MediaUsecase interface {
+	// UploadMedia stores the file, filesURL is where the app serves files from when the store can't serve them itself.
+	UploadMedia(ctx context.Context, username string, data dto.UploadMediaRequest, filesURL string) (*dto.MediaResponse, *helpers.Error)
+	GetMyMedia(ctx context.Context, username string, page repository.PageRequest, filesURL string) ([]dto.MediaResponse, *helpers.Pagination, *helpers.Error)
+	DeleteMedia(ctx context.Context, username, mediaID string) *helpers.Error
+	OpenMedia(ctx context.Context, key string) (*dto.MediaFile, *helpers.Error)
+	// ProcessPendingMedia resizes and strips uploaded images, uploads return before they're processed.
+	ProcessPendingMedia(ctx context.Context) error
+}
+
+// allowedTypes maps the content types that may be uploaded to their file extension. The type is sniffed from the
+// content, what the client claims is ignored. SVG isn't allowed, it can carry scripts.
+var allowedTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+const (
+	defaultMaxSizeMB = 5
+	defaultQuotaMB   = 100
+	sniffLength      = 512
+	// how many media ProcessPendingMedia claims at a time
+	processBatchSize = 10
+	// media still processing after this long are assumed to be stuck, e.g. the app stopped, and processed again
+	processingTimeout = 5 * time.Minute
+	maxAttempts       = 3
+)
+
+// Limits caps the size of a single upload and of all of a user's uploads, in bytes.
+type Limits struct {
+	MaxSize int64
+	Quota   int64
+}
+
+// LimitsFromEnv reads MEDIA_MAX_SIZE_MB and MEDIA_QUOTA_MB, falling back to 5 MB and 100 MB.
+func LimitsFromEnv() (Limits, error) {
+	maxSize, err := megabytesFromEnv("MEDIA_MAX_SIZE_MB", defaultMaxSizeMB)
+	if err != nil {
+		return Limits{}, err
+	}
+
+	quota, err := megabytesFromEnv("MEDIA_QUOTA_MB", defaultQuotaMB)
+	if err != nil {
+		return Limits{}, err
+	}
+
+	return Limits{MaxSize: maxSize, Quota: quota}, nil
+}
+
+func megabytesFromEnv(name string, fallback int64) (int64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback << 20, nil
+	}
+
+	mb, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || mb <= 0 {
+		return 0, fmt.Errorf("invalid %s %s, must be a positive number of megabytes", name, value)
+	}
+	return mb << 20, nil
+}
+
+type mediaUsecaseImpl struct {
+	repo   repository.MediaRepository
+	store  storage.BlobStore
+	limits Limits
+	logger *slog.Logger
+}
+
+func NewMediaUsecase(repo repository.MediaRepository, store storage.BlobStore, limits Limits, logger *slog.Logger) MediaUsecase {
+	return &mediaUsecaseImpl{
+		repo:   repo,
+		store:  store,
+		limits: limits,
+		logger: logger,
+	}
+}
+
+func (uc *mediaUsecaseImpl) UploadMedia(ctx context.Context, username string, data dto.UploadMediaRequest, filesURL string) (*dto.MediaResponse, *helpers.Error) {
+	tooLarge := helpers.ErrorBuilder(http.StatusRequestEntityTooLarge, fmt.Sprintf("file must not be larger than %d MB", uc.limits.MaxSize>>20))
+	if data.File.Size > uc.limits.MaxSize {
+		return nil, tooLarge
+	}
+
+	file, err := data.File.Open()
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	defer file.Close()
+
+	// the declared size isn't trusted either, one byte more than allowed is enough to tell it's too large
+	content, err := io.ReadAll(io.LimitReader(file, uc.limits.MaxSize+1))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	if int64(len(content)) > uc.limits.MaxSize {
+		return nil, tooLarge
+	}
+	if len(content) == 0 {
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "file must not be empty")
+	}
+
+	contentType := http.DetectContentType(content[:min(len(content), sniffLength)])
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return nil, helpers.ErrorBuilder(http.StatusUnsupportedMediaType, "file must be a PNG, JPEG, GIF or WebP image")
+	}
+
+	// concurrent uploads may go slightly over the quota, it's a limit on storage use rather than a hard guarantee
+	used, err := uc.repo.TotalSizeByUsername(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	if used+int64(len(content)) > uc.limits.Quota {
+		return nil, helpers.ErrorBuilder(http.StatusRequestEntityTooLarge, fmt.Sprintf("uploading this file would go over your %d MB quota, delete some of your media first", uc.limits.Quota>>20))
+	}
+
+	random, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	// the key keeps the username the file was uploaded under, even once the user is renamed. It's only a place in the
+	// store, media are always found through their record, never by the username in their key
+	key := username + "/" + random + ext
+
+	err = uc.store.Put(ctx, key, content, contentType)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	media, err := uc.repo.Create(ctx, model.Media{
+		Username:    username,
+		Key:         key,
+		Filename:    cleanFilename(data.File.Filename, ext),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Status:      model.MediaStatusPending,
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		uc.deleteBlob(ctx, key)
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	resp := uc.toMediaResponse(*media, filesURL)
+	return &resp, nil
+}
+
+// cleanFilename keeps the name the file was uploaded with for display only, it's never used as a path.
+func cleanFilename(filename, ext string) string {
+	name := strings.TrimSpace(path.Base(strings.ReplaceAll(filename, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		return "upload" + ext
+	}
+
+	if utf8.RuneCountInString(name) > 255 {
+		name = string([]rune(name)[:255])
+	}
+	return name
+}
+
+func (uc *mediaUsecaseImpl) GetMyMedia(ctx context.Context, username string, page repository.PageRequest, filesURL string) ([]dto.MediaResponse, *helpers.Pagination, *helpers.Error) {
+	mediaData := make([]dto.MediaResponse, 0)
+
+	media, err := uc.repo.FindByUsername(ctx, username, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	media, pagination := helpers.BuildPage(media, page, func(media model.Media) repository.Cursor {
+		return repository.Cursor{CreatedAt: media.CreatedAt, ID: media.ID}
+	})
+	for _, m := range media {
+		mediaData = append(mediaData, uc.toMediaResponse(m, filesURL))
+	}
+
+	return mediaData, pagination, nil
+}
+
+// DeleteMedia doesn't check whether posts still use the file, their images simply stop loading.
+func (uc *mediaUsecaseImpl) DeleteMedia(ctx context.Context, username, mediaID string) *helpers.Error {
+	id, err := strconv.ParseUint(mediaID, 10, 64)
+	if err != nil {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "media id must be a positive integer")
+	}
+
+	media, err := uc.repo.FindByIDAndUsername(ctx, uint(id), username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("media with id %s not found", mediaID))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// the record goes first, a blob left behind only wastes storage while a record left behind would count
+	// towards the quota and point at nothing
+	err = uc.repo.Delete(ctx, *media)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	uc.deleteBlob(ctx, media.Key)
+	for _, variant := range media.Variants {
+		uc.deleteBlob(ctx, variant.Key)
+	}
+
+	return nil
+}
+
+func (uc *mediaUsecaseImpl) deleteBlob(ctx context.Context, key string) {
+	err := uc.store.Delete(ctx, key)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+	}
+}
+
+// OpenMedia only serves processed variants, the upload itself may still carry metadata such as GPS coordinates.
+func (uc *mediaUsecaseImpl) OpenMedia(ctx context.Context, key string) (*dto.MediaFile, *helpers.Error) {
+	notFound := helpers.ErrorBuilder(http.StatusNotFound, "media not found")
+
+	variant, err := uc.repo.FindVariantByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFound
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	body, err := uc.store.Open(ctx, variant.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, notFound
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return &dto.MediaFile{
+		Filename:    path.Base(variant.Key),
+		ContentType: variant.ContentType,
+		Size:        variant.Size,
+		Body:        body,
+	}, nil
+}
+
+func (uc *mediaUsecaseImpl) ProcessPendingMedia(ctx context.Context) error {
+	count := 0
+	for {
+		media, err := uc.repo.ClaimPending(ctx, time.Now().Add(-processingTimeout), processBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range media {
+			// a media that can't be processed for now is left processing, it's claimed again once it times out
+			err := uc.processMedia(ctx, m)
+			if err != nil {
+				uc.logger.ErrorContext(ctx, err.Error(), "media_id", m.ID)
+			}
+		}
+		count += len(media)
+
+		if len(media) < processBatchSize {
+			break
+		}
+	}
+
+	if count > 0 {
+		uc.logger.InfoContext(ctx, "processed media", "count", count)
+	}
+
+	return nil
+}
+
+func (uc *mediaUsecaseImpl) processMedia(ctx context.Context, media model.Media) error {
+	if media.Attempts > maxAttempts {
+		return uc.repo.UpdateStatus(ctx, media.ID, model.MediaStatusFailed, "processing kept failing, please upload the image again")
+	}
+
+	body, err := uc.store.Open(ctx, media.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return uc.repo.UpdateStatus(ctx, media.ID, model.MediaStatusFailed, "the uploaded file is missing, please upload the image again")
+		}
+		return err
+	}
+	content, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		return err
+	}
+
+	images, err := utils.ProcessImage(content)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidImage) {
+			uc.logger.InfoContext(ctx, err.Error(), "media_id", media.ID)
+			return uc.repo.UpdateStatus(ctx, media.ID, model.MediaStatusFailed, "the image couldn't be read, it may be damaged or too large")
+		}
+		return err
+	}
+
+	// variants are named after the upload, e.g. johndoe/abc-640w.jpg for johndoe/abc.png
+	base := strings.TrimSuffix(media.Key, path.Ext(media.Key))
+	variants := make([]model.MediaVariant, 0, len(images))
+	media.Size = 0
+	for _, img := range images {
+		variant := model.MediaVariant{
+			MediaID:     media.ID,
+			Key:         fmt.Sprintf("%s-%dw%s", base, img.Width, img.Ext),
+			Width:       img.Width,
+			Height:      img.Height,
+			ContentType: img.ContentType,
+			Size:        int64(len(img.Data)),
+		}
+
+		err = uc.store.Put(ctx, variant.Key, img.Data, variant.ContentType)
+		if err != nil {
+			uc.deleteVariantBlobs(ctx, variants)
+			return err
+		}
+
+		variants = append(variants, variant)
+		media.Size += variant.Size
+		media.Width, media.Height = img.Width, img.Height
+	}
+
+	err = uc.repo.SaveProcessed(ctx, media, variants)
+	if err != nil {
+		uc.deleteVariantBlobs(ctx, variants)
+		// deleted while it was being processed, there's nothing left to do
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// the upload isn't needed anymore, and it's better not to keep its metadata around
+	uc.deleteBlob(ctx, media.Key)
+
+	return nil
+}
+
+func (uc *mediaUsecaseImpl) deleteVariantBlobs(ctx context.Context, variants []model.MediaVariant) {
+	for _, variant := range variants {
+		uc.deleteBlob(ctx, variant.Key)
+	}
+}
+
+func (uc *mediaUsecaseImpl) variantURL(key, filesURL string) string {
+	url, ok := uc.store.PublicURL(key)
+	if !ok {
+		url = filesURL + "/" + key
+	}
+	return url
+}
+
+func (uc *mediaUsecaseImpl) toMediaResponse(media model.Media, filesURL string) dto.MediaResponse {
+	resp := dto.MediaResponse{
+		ID:              media.ID,
+		Filename:        media.Filename,
+		Status:          media.Status,
+		ProcessingError: media.ProcessingError,
+		Size:            media.Size,
+		Width:           media.Width,
+		Height:          media.Height,
+		Variants:        make([]dto.MediaVariantResponse, 0, len(media.Variants)),
+		CreatedAt:       media.CreatedAt,
+	}
+
+	srcset := make([]string, 0, len(media.Variants))
+	for _, variant := range media.Variants {
+		url := uc.variantURL(variant.Key, filesURL)
+		resp.Variants = append(resp.Variants, dto.MediaVariantResponse{
+			Width:       variant.Width,
+			Height:      variant.Height,
+			ContentType: variant.ContentType,
+			Size:        variant.Size,
+			URL:         url,
+		})
+		srcset = append(srcset, fmt.Sprintf("%s %dw", url, variant.Width))
+		// variants are sorted by width, the last one is the largest
+		resp.URL = url
+	}
+	resp.Srcset = strings.Join(srcset, ", ")
+
+	if resp.URL != "" {
+		alt := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(media.Filename)
+		resp.Markdown = fmt.Sprintf("![%s](%s)", alt, resp.URL)
+	}
+
+	return resp
+}
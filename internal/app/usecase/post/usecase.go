@@ -8,31 +8,49 @@ import (
 	"goproject/internal/domain/model"
 	"goproject/internal/domain/repository"
 	"goproject/internal/helpers"
+	"goproject/internal/utils"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type PostUsecase interface {
 	CreateNewPost(ctx context.Context, username string, data dto.PostRequest) (*dto.CreatePostResponse, *helpers.Error)
-	GetPostsByBlogOwner(ctx context.Context, username string) ([]dto.PostResponse, *helpers.Error)
+	GetPostsByBlogOwner(ctx context.Context, username, tag string, page repository.PageRequest) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error)
+	GetMyPosts(ctx context.Context, username, tag string, page repository.PageRequest) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error)
+	GetFeed(ctx context.Context, username string, page repository.PageRequest) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error)
 	GetPostBySlug(ctx context.Context, username, slug string) (*dto.PostResponse, *helpers.Error)
+	GetMyPostBySlug(ctx context.Context, username, slug string) (*dto.PostResponse, *helpers.Error)
 	UpdatePostBySlug(ctx context.Context, data dto.PostRequest, username, slug string) *helpers.Error
 	DeletePostBySlug(ctx context.Context, username, slug string) *helpers.Error
+	PublishScheduledPosts(ctx context.Context) error
+	GetMyPostRevisions(ctx context.Context, username, slug string, page repository.PageRequest) ([]dto.RevisionResponse, *helpers.Pagination, *helpers.Error)
+	GetMyPostRevision(ctx context.Context, username, slug, revision string) (*dto.RevisionResponse, *helpers.Error)
+	DiffMyPostRevisions(ctx context.Context, username, slug, from, to string) (*dto.RevisionDiffResponse, *helpers.Error)
+	RestoreMyPostRevision(ctx context.Context, username, slug, revision string) *helpers.Error
+	PreviewContent(ctx context.Context, data dto.PreviewRequest) (*dto.PreviewResponse, *helpers.Error)
+	RenderStalePosts(ctx context.Context) error
 }
 
+// how many posts RenderStalePosts loads at a time
+const renderBatchSize = 100
+
 type postUsecaseImpl struct {
-	postRepo repository.PostRepository
-	blogRepo repository.BlogRepository
-	logger   *slog.Logger
+	postRepo     repository.PostRepository
+	revisionRepo repository.PostRevisionRepository
+	blogRepo     repository.BlogRepository
+	logger       *slog.Logger
 }
 
-func NewPostUsecase(postRepo repository.PostRepository, blogRepo repository.BlogRepository, logger *slog.Logger) PostUsecase {
+func NewPostUsecase(postRepo repository.PostRepository, revisionRepo repository.PostRevisionRepository, blogRepo repository.BlogRepository, logger *slog.Logger) PostUsecase {
 	return &postUsecaseImpl{
-		postRepo: postRepo,
-		blogRepo: blogRepo,
-		logger:   logger,
+		postRepo:     postRepo,
+		revisionRepo: revisionRepo,
+		blogRepo:     blogRepo,
+		logger:       logger,
 	}
 }
 
@@ -54,6 +72,23 @@ func (uc *postUsecaseImpl) CreateNewPost(ctx context.Context, username string, d
 		BlogID:  blog.ID,
 	}
 
+	ucErr := uc.renderContent(ctx, &postData)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	// posts created without a status are published right away, like they always were
+	if data.Status == "" {
+		data.Status = model.PostStatusPublished
+	}
+
+	ucErr = setPostStatus(&postData, data.Status, data.PublishAt)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	postData.Tags = helpers.NormalizeTags(data.Tags)
+
 	err = uc.postRepo.Create(ctx, postData)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
@@ -65,40 +100,59 @@ func (uc *postUsecaseImpl) CreateNewPost(ctx context.Context, username string, d
 	return resp, nil
 }
 
-func (uc *postUsecaseImpl) GetPostsByBlogOwner(ctx context.Context, username string) ([]dto.PostResponse, *helpers.Error) {
+func (uc *postUsecaseImpl) GetPostsByBlogOwner(ctx context.Context, username, tag string, page repository.PageRequest) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error) {
+	return uc.getPostsByBlogOwner(ctx, username, tag, page, uc.postRepo.FindPublishedByBlogID, publishedPostCursor)
+}
+
+func (uc *postUsecaseImpl) GetMyPosts(ctx context.Context, username, tag string, page repository.PageRequest) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error) {
+	return uc.getPostsByBlogOwner(ctx, username, tag, page, uc.postRepo.FindByBlogID, postCursor)
+}
+
+func (uc *postUsecaseImpl) GetFeed(ctx context.Context, username string, page repository.PageRequest) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error) {
+	postsData := make([]dto.PostResponse, 0)
+
+	posts, err := uc.postRepo.FindFeed(ctx, username, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	posts, pagination := helpers.BuildPage(posts, page, publishedPostCursor)
+	for _, post := range posts {
+		postsData = append(postsData, dto.NewPostResponse(post))
+	}
+
+	return postsData, pagination, nil
+}
+
+func (uc *postUsecaseImpl) getPostsByBlogOwner(ctx context.Context, username, tag string, page repository.PageRequest, find func(ctx context.Context, blogID uint, tag string, page repository.PageRequest) ([]model.Post, error), cursorOf func(post model.Post) repository.Cursor) ([]dto.PostResponse, *helpers.Pagination, *helpers.Error) {
 	postsData := make([]dto.PostResponse, 0)
 
 	blog, err := uc.blogRepo.FindByOwner(ctx, username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s's blog not found", username))
+			return nil, nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s's blog not found", username))
 		}
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	posts, err := uc.postRepo.FindByBlogID(ctx, blog.ID)
+	posts, err := find(ctx, blog.ID, helpers.SlugifyTag(tag), page)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
-		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
+	posts, pagination := helpers.BuildPage(posts, page, cursorOf)
 	for _, post := range posts {
-		postsData = append(postsData, dto.PostResponse{
-			Title:     post.Title,
-			Content:   post.Content,
-			Slug:      post.Slug,
-			Author:    post.Blog.User.Name,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
-		})
+		postsData = append(postsData, dto.NewPostResponse(post))
 	}
 
-	return postsData, nil
+	return postsData, pagination, nil
 }
 
 func (uc *postUsecaseImpl) GetPostBySlug(ctx context.Context, username, slug string) (*dto.PostResponse, *helpers.Error) {
-	post, err := uc.postRepo.FindBySlugAndOwner(ctx, slug, username)
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, slug, username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", slug, username))
@@ -106,15 +160,21 @@ func (uc *postUsecaseImpl) GetPostBySlug(ctx context.Context, username, slug str
 		uc.logger.ErrorContext(ctx, err.Error())
 		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
-	data := &dto.PostResponse{
-		Title:     post.Title,
-		Slug:      post.Slug,
-		Content:   post.Content,
-		Author:    post.Blog.User.Name,
-		CreatedAt: post.CreatedAt,
-		UpdatedAt: post.UpdatedAt,
+	data := dto.NewPostResponse(*post)
+	return &data, nil
+}
+
+func (uc *postUsecaseImpl) GetMyPostBySlug(ctx context.Context, username, slug string) (*dto.PostResponse, *helpers.Error) {
+	post, err := uc.postRepo.FindBySlugAndOwner(ctx, slug, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post with slug %s not found", slug))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
-	return data, nil
+	data := dto.NewPostResponse(*post)
+	return &data, nil
 }
 
 func (uc *postUsecaseImpl) UpdatePostBySlug(ctx context.Context, data dto.PostRequest, username, slug string) *helpers.Error {
@@ -130,7 +190,26 @@ func (uc *postUsecaseImpl) UpdatePostBySlug(ctx context.Context, data dto.PostRe
 	post.Title = data.Title
 	post.Content = data.Content
 
-	err = uc.postRepo.Update(ctx, *post)
+	ucErr := uc.renderContent(ctx, post)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	// omitting the status on update keeps the current one
+	if data.Status != "" {
+		ucErr := setPostStatus(post, data.Status, data.PublishAt)
+		if ucErr != nil {
+			return ucErr
+		}
+	}
+
+	// omitting the tags on update keeps the current ones, an empty array removes them
+	var tags []model.Tag
+	if data.Tags != nil {
+		tags = helpers.NormalizeTags(data.Tags)
+	}
+
+	err = uc.postRepo.Update(ctx, *post, model.PostRevision{}, tags)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
@@ -157,3 +236,265 @@ func (uc *postUsecaseImpl) DeletePostBySlug(ctx context.Context, username, slug
 
 	return nil
 }
+
+func (uc *postUsecaseImpl) PublishScheduledPosts(ctx context.Context) error {
+	n, err := uc.postRepo.PublishScheduled(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		uc.logger.InfoContext(ctx, "published scheduled posts", "count", n)
+	}
+
+	return nil
+}
+
+func (uc *postUsecaseImpl) GetMyPostRevisions(ctx context.Context, username, slug string, page repository.PageRequest) ([]dto.RevisionResponse, *helpers.Pagination, *helpers.Error) {
+	revisionsData := make([]dto.RevisionResponse, 0)
+
+	post, ucErr := uc.findMyPost(ctx, username, slug)
+	if ucErr != nil {
+		return nil, nil, ucErr
+	}
+
+	revisions, err := uc.revisionRepo.FindByPostID(ctx, post.ID, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	revisions, pagination := helpers.BuildPage(revisions, page, func(revision model.PostRevision) repository.Cursor {
+		return repository.Cursor{CreatedAt: revision.CreatedAt, ID: revision.ID}
+	})
+	for _, revision := range revisions {
+		revisionsData = append(revisionsData, dto.RevisionResponse{
+			Revision:     revision.Revision,
+			Title:        revision.Title,
+			RestoredFrom: revision.RestoredFrom,
+			CreatedAt:    revision.CreatedAt,
+		})
+	}
+
+	return revisionsData, pagination, nil
+}
+
+func (uc *postUsecaseImpl) GetMyPostRevision(ctx context.Context, username, slug, revision string) (*dto.RevisionResponse, *helpers.Error) {
+	post, ucErr := uc.findMyPost(ctx, username, slug)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	postRevision, ucErr := uc.findPostRevision(ctx, *post, revision)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	data := &dto.RevisionResponse{
+		Revision:     postRevision.Revision,
+		Title:        postRevision.Title,
+		Content:      postRevision.Content,
+		RestoredFrom: postRevision.RestoredFrom,
+		CreatedAt:    postRevision.CreatedAt,
+	}
+	return data, nil
+}
+
+func (uc *postUsecaseImpl) DiffMyPostRevisions(ctx context.Context, username, slug, from, to string) (*dto.RevisionDiffResponse, *helpers.Error) {
+	post, ucErr := uc.findMyPost(ctx, username, slug)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	fromRevision, ucErr := uc.findPostRevision(ctx, *post, from)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	toRevision, ucErr := uc.findPostRevision(ctx, *post, to)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	data := &dto.RevisionDiffResponse{
+		From:    fromRevision.Revision,
+		To:      toRevision.Revision,
+		Title:   utils.DiffLines(fromRevision.Title, toRevision.Title),
+		Content: utils.DiffLines(fromRevision.Content, toRevision.Content),
+	}
+	return data, nil
+}
+
+func (uc *postUsecaseImpl) RestoreMyPostRevision(ctx context.Context, username, slug, revision string) *helpers.Error {
+	post, ucErr := uc.findMyPost(ctx, username, slug)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	postRevision, ucErr := uc.findPostRevision(ctx, *post, revision)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	post.Title = postRevision.Title
+	post.Content = postRevision.Content
+
+	ucErr = uc.renderContent(ctx, post)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	err := uc.postRepo.Update(ctx, *post, model.PostRevision{
+		RestoredFrom: &postRevision.Revision,
+	}, nil)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *postUsecaseImpl) PreviewContent(ctx context.Context, data dto.PreviewRequest) (*dto.PreviewResponse, *helpers.Error) {
+	rendered, err := utils.RenderMarkdown(data.Content)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return &dto.PreviewResponse{
+		ContentHTML:        rendered.HTML,
+		Excerpt:            rendered.Excerpt,
+		ReadingTimeMinutes: rendered.ReadingTimeMinutes,
+	}, nil
+}
+
+// RenderStalePosts renders the posts that were never rendered, or were rendered by an older renderer.
+func (uc *postUsecaseImpl) RenderStalePosts(ctx context.Context) error {
+	count, failed := 0, 0
+	// the posts that fail are left behind, the next run tries them again without holding up the others
+	var lastID uint
+	for {
+		posts, err := uc.postRepo.FindRenderedBefore(ctx, utils.MarkdownRenderVersion, lastID, renderBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			lastID = post.ID
+
+			rendered, err := utils.RenderMarkdown(post.Content)
+			if err != nil {
+				uc.logger.ErrorContext(ctx, "post not rendered", "post_id", post.ID, "error", err.Error())
+				failed++
+				continue
+			}
+			setRenderedContent(&post, rendered)
+
+			err = uc.postRepo.UpdateRendered(ctx, post)
+			if err != nil {
+				uc.logger.ErrorContext(ctx, "rendered post not saved", "post_id", post.ID, "error", err.Error())
+				failed++
+				continue
+			}
+			count++
+		}
+
+		if len(posts) < renderBatchSize {
+			break
+		}
+	}
+
+	if count > 0 {
+		uc.logger.InfoContext(ctx, "rendered stale posts", "count", count)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d stale posts couldn't be rendered", failed)
+	}
+
+	return nil
+}
+
+func (uc *postUsecaseImpl) renderContent(ctx context.Context, post *model.Post) *helpers.Error {
+	rendered, err := utils.RenderMarkdown(post.Content)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	setRenderedContent(post, rendered)
+	return nil
+}
+
+func setRenderedContent(post *model.Post, rendered utils.RenderedMarkdown) {
+	post.ContentHTML = rendered.HTML
+	post.Excerpt = rendered.Excerpt
+	post.ReadingTimeMinutes = rendered.ReadingTimeMinutes
+	post.RenderVersion = utils.MarkdownRenderVersion
+}
+
+func (uc *postUsecaseImpl) findMyPost(ctx context.Context, username, slug string) (*model.Post, *helpers.Error) {
+	post, err := uc.postRepo.FindBySlugAndOwner(ctx, slug, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post with slug %s not found", slug))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return post, nil
+}
+
+func (uc *postUsecaseImpl) findPostRevision(ctx context.Context, post model.Post, revision string) (*model.PostRevision, *helpers.Error) {
+	revisionNumber, err := strconv.ParseUint(revision, 10, 64)
+	if err != nil {
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "revision must be a positive integer")
+	}
+
+	postRevision, err := uc.revisionRepo.FindByPostIDAndRevision(ctx, post.ID, uint(revisionNumber))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("revision %s of post %s not found", revision, post.Slug))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return postRevision, nil
+}
+
+func postCursor(post model.Post) repository.Cursor {
+	return repository.Cursor{CreatedAt: post.CreatedAt, ID: post.ID}
+}
+
+// publishedPostCursor is the cursor of a post in a collection ordered by repository.PageRequest.PublishedScope.
+func publishedPostCursor(post model.Post) repository.Cursor {
+	return repository.Cursor{PublishedAt: post.PublishedAt, ID: post.ID}
+}
+
+func setPostStatus(post *model.Post, status string, publishAt *time.Time) *helpers.Error {
+	now := time.Now()
+
+	switch status {
+	case model.PostStatusDraft:
+		post.PublishedAt = nil
+	case model.PostStatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "publish_at must be in the future to schedule a post")
+		}
+		post.PublishedAt = publishAt
+	case model.PostStatusPublished:
+		// already published or archived posts keep their original publish date
+		if post.PublishedAt == nil || (post.Status != model.PostStatusPublished && post.Status != model.PostStatusArchived) {
+			post.PublishedAt = &now
+		}
+	case model.PostStatusArchived:
+		if post.Status != model.PostStatusPublished {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "only published posts can be archived")
+		}
+	}
+
+	post.Status = status
+	return nil
+}
@@ -0,0 +1,96 @@
+package reportusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/report/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+type ReportUsecase interface {
+	ReportPost(ctx context.Context, data dto.ReportRequest, username, blogOwner, postSlug string) (uint, *helpers.Error)
+	ReportComment(ctx context.Context, data dto.ReportRequest, username, blogOwner, postSlug, commentID string) (uint, *helpers.Error)
+}
+
+type reportUsecaseImpl struct {
+	reportRepo  repository.ReportRepository
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	logger      *slog.Logger
+}
+
+func NewReportUsecase(reportRepo repository.ReportRepository, postRepo repository.PostRepository, commentRepo repository.CommentRepository, logger *slog.Logger) ReportUsecase {
+	return &reportUsecaseImpl{
+		reportRepo:  reportRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		logger:      logger,
+	}
+}
+
+func (uc *reportUsecaseImpl) ReportPost(ctx context.Context, data dto.ReportRequest, username, blogOwner, postSlug string) (uint, *helpers.Error) {
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return 0, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return uc.create(ctx, model.Report{
+		Reporter: username,
+		PostID:   post.ID,
+		Reason:   data.Reason,
+	})
+}
+
+func (uc *reportUsecaseImpl) ReportComment(ctx context.Context, data dto.ReportRequest, username, blogOwner, postSlug, commentID string) (uint, *helpers.Error) {
+	post, err := uc.postRepo.FindPublishedBySlugAndOwner(ctx, postSlug, blogOwner)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("post %s on %s's blog not found", postSlug, blogOwner))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return 0, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	id, err := strconv.ParseUint(commentID, 10, 64)
+	if err != nil {
+		return 0, helpers.ErrorBuilder(http.StatusBadRequest, "comment id must be a positive integer")
+	}
+
+	comment, err := uc.commentRepo.FindCommentByID(ctx, uint(id))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return 0, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if err != nil || comment.PostID != post.ID || comment.DeletedAt != nil {
+		return 0, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("comment with id %s not found on this post", commentID))
+	}
+
+	return uc.create(ctx, model.Report{
+		Reporter:  username,
+		PostID:    post.ID,
+		CommentID: &comment.ID,
+		Reason:    data.Reason,
+	})
+}
+
+func (uc *reportUsecaseImpl) create(ctx context.Context, data model.Report) (uint, *helpers.Error) {
+	id, err := uc.reportRepo.Create(ctx, data)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return 0, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	return id, nil
+}
@@ -0,0 +1,56 @@
+package searchusecase
+
+import (
+	"context"
+	postDto "goproject/internal/app/delivery/http/post/dto"
+	"goproject/internal/app/delivery/http/search/dto"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type SearchUsecase interface {
+	SearchPosts(ctx context.Context, query, username string, page repository.PageRequest) ([]dto.PostSearchResponse, *helpers.Pagination, *helpers.Error)
+}
+
+type searchUsecaseImpl struct {
+	postRepo repository.PostRepository
+	logger   *slog.Logger
+}
+
+func NewSearchUsecase(postRepo repository.PostRepository, logger *slog.Logger) SearchUsecase {
+	return &searchUsecaseImpl{
+		postRepo: postRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *searchUsecaseImpl) SearchPosts(ctx context.Context, query, username string, page repository.PageRequest) ([]dto.PostSearchResponse, *helpers.Pagination, *helpers.Error) {
+	resultsData := make([]dto.PostSearchResponse, 0)
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil, helpers.ErrorBuilder(http.StatusBadRequest, "search query (q) can't be empty")
+	}
+
+	results, err := uc.postRepo.Search(ctx, query, username, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	results, pagination := helpers.BuildPage(results, page, func(result repository.PostSearchResult) repository.Cursor {
+		return repository.Cursor{Rank: result.Rank, ID: result.Post.ID}
+	})
+	for _, result := range results {
+		resultsData = append(resultsData, dto.PostSearchResponse{
+			PostResponse: postDto.NewPostResponse(result.Post),
+			Snippet:      result.Snippet,
+			Rank:         result.Rank,
+		})
+	}
+
+	return resultsData, pagination, nil
+}
@@ -0,0 +1,94 @@
+package tagusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	postDto "goproject/internal/app/delivery/http/post/dto"
+	"goproject/internal/app/delivery/http/tag/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"log/slog"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type TagUsecase interface {
+	GetPostsByTag(ctx context.Context, tag string, page repository.PageRequest) ([]postDto.PostResponse, *helpers.Pagination, *helpers.Error)
+	GetTagsByBlogOwner(ctx context.Context, username string) ([]dto.TagResponse, *helpers.Error)
+}
+
+type tagUsecaseImpl struct {
+	tagRepo  repository.TagRepository
+	postRepo repository.PostRepository
+	blogRepo repository.BlogRepository
+	logger   *slog.Logger
+}
+
+func NewTagUsecase(tagRepo repository.TagRepository, postRepo repository.PostRepository, blogRepo repository.BlogRepository, logger *slog.Logger) TagUsecase {
+	return &tagUsecaseImpl{
+		tagRepo:  tagRepo,
+		postRepo: postRepo,
+		blogRepo: blogRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *tagUsecaseImpl) GetPostsByTag(ctx context.Context, tag string, page repository.PageRequest) ([]postDto.PostResponse, *helpers.Pagination, *helpers.Error) {
+	postsData := make([]postDto.PostResponse, 0)
+
+	tagData, err := uc.tagRepo.FindBySlug(ctx, helpers.SlugifyTag(tag))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("tag %s not found", tag))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	posts, err := uc.postRepo.FindPublishedByTagID(ctx, tagData.ID, page)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	posts, pagination := helpers.BuildPage(posts, page, func(post model.Post) repository.Cursor {
+		return repository.Cursor{PublishedAt: post.PublishedAt, ID: post.ID}
+	})
+	for _, post := range posts {
+		postsData = append(postsData, postDto.NewPostResponse(post))
+	}
+
+	return postsData, pagination, nil
+}
+
+func (uc *tagUsecaseImpl) GetTagsByBlogOwner(ctx context.Context, username string) ([]dto.TagResponse, *helpers.Error) {
+	tagsData := make([]dto.TagResponse, 0)
+
+	blog, err := uc.blogRepo.FindByOwner(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s's blog not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	tags, err := uc.tagRepo.CountPublishedPostsByBlogID(ctx, blog.ID)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	for _, tag := range tags {
+		tagsData = append(tagsData, dto.TagResponse{
+			Name:       tag.Name,
+			Slug:       tag.Slug,
+			NumOfPosts: tag.NumOfPosts,
+		})
+	}
+
+	return tagsData, nil
+}
@@ -0,0 +1,269 @@
+package twofactorusecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/twofactor/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/utils"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TwoFactorUsecase interface {
+	Enroll(ctx context.Context, username string) (*dto.EnrollResponse, *helpers.Error)
+	Confirm(ctx context.Context, username string, data dto.CodeRequest) (*dto.RecoveryCodesResponse, *helpers.Error)
+	Disable(ctx context.Context, username string, data dto.DisableRequest) *helpers.Error
+	RegenerateRecoveryCodes(ctx context.Context, username string, data dto.CodeRequest) (*dto.RecoveryCodesResponse, *helpers.Error)
+	VerifyCode(ctx context.Context, user model.User, code string) *helpers.Error
+}
+
+const (
+	totpIssuer         = "Go-Blog"
+	numOfRecoveryCodes = 10
+	// 32 characters, so every random byte maps to one of them evenly. 0, 1, l and o are left out as they're easy to mix up
+	recoveryCodeCharset = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+type twoFactorUsecaseImpl struct {
+	userRepo         repository.UserRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	logger           *slog.Logger
+}
+
+func NewTwoFactorUsecase(userRepo repository.UserRepository, recoveryCodeRepo repository.RecoveryCodeRepository, logger *slog.Logger) TwoFactorUsecase {
+	return &twoFactorUsecaseImpl{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		logger:           logger,
+	}
+}
+
+func (uc *twoFactorUsecaseImpl) Enroll(ctx context.Context, username string) (*dto.EnrollResponse, *helpers.Error) {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userRepo.SetTOTPSecret(ctx, username, secret)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return &dto.EnrollResponse{
+		Secret: secret,
+		URI:    utils.TOTPURI(totpIssuer, username, secret),
+	}, nil
+}
+
+func (uc *twoFactorUsecaseImpl) Confirm(ctx context.Context, username string, data dto.CodeRequest) (*dto.RecoveryCodesResponse, *helpers.Error) {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, helpers.ErrorBuilder(http.StatusConflict, "two-factor authentication is already enabled")
+	}
+
+	if user.TOTPSecret == nil {
+		return nil, helpers.ErrorBuilder(http.StatusBadRequest, "start the enrollment first")
+	}
+
+	// only a code from the authenticator proves it was set up correctly, recovery codes don't exist yet anyway
+	ucErr = uc.verifyTOTP(ctx, *user, data.Code)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	resp, ucErr := uc.replaceRecoveryCodes(ctx, username)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	err := uc.userRepo.EnableTOTP(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return resp, nil
+}
+
+func (uc *twoFactorUsecaseImpl) Disable(ctx context.Context, username string, data dto.DisableRequest) *helpers.Error {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return helpers.ErrorBuilder(http.StatusConflict, "two-factor authentication isn't enabled")
+	}
+
+	err := utils.IsValidPassword(user.Password, data.Password)
+	if err != nil {
+		if errors.Is(err, utils.ErrNoPassword) {
+			return helpers.ErrorBuilder(http.StatusConflict, "you haven't set a password yet, set one through forgot password")
+		}
+		return helpers.ErrorBuilder(http.StatusUnauthorized, "password you provided is incorrect")
+	}
+
+	ucErr = uc.VerifyCode(ctx, *user, data.Code)
+	if ucErr != nil {
+		return ucErr
+	}
+
+	err = uc.userRepo.DisableTOTP(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.recoveryCodeRepo.DeleteByUsername(ctx, username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *twoFactorUsecaseImpl) RegenerateRecoveryCodes(ctx context.Context, username string, data dto.CodeRequest) (*dto.RecoveryCodesResponse, *helpers.Error) {
+	user, ucErr := uc.findUser(ctx, username)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return nil, helpers.ErrorBuilder(http.StatusConflict, "two-factor authentication isn't enabled")
+	}
+
+	ucErr = uc.verifyTOTP(ctx, *user, data.Code)
+	if ucErr != nil {
+		return nil, ucErr
+	}
+
+	return uc.replaceRecoveryCodes(ctx, username)
+}
+
+// VerifyCode accepts either a code from the user's authenticator or one of their unused recovery codes.
+func (uc *twoFactorUsecaseImpl) VerifyCode(ctx context.Context, user model.User, code string) *helpers.Error {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) == 6 {
+		return uc.verifyTOTP(ctx, user, code)
+	}
+
+	n, err := uc.recoveryCodeRepo.Consume(ctx, user.Username, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if n == 0 {
+		return helpers.ErrorBuilder(http.StatusUnauthorized, "invalid two-factor code")
+	}
+
+	return nil
+}
+
+func (uc *twoFactorUsecaseImpl) verifyTOTP(ctx context.Context, user model.User, code string) *helpers.Error {
+	if user.TOTPSecret == nil {
+		return helpers.ErrorBuilder(http.StatusUnauthorized, "invalid two-factor code")
+	}
+
+	step, ok := utils.ValidateTOTP(*user.TOTPSecret, strings.ReplaceAll(code, " ", ""), time.Now())
+	if !ok {
+		return helpers.ErrorBuilder(http.StatusUnauthorized, "invalid two-factor code")
+	}
+
+	// a code stays valid for a while, make sure nobody who saw it can use it again
+	n, err := uc.userRepo.UseTOTPStep(ctx, user.Username, step)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if n == 0 {
+		return helpers.ErrorBuilder(http.StatusUnauthorized, "two-factor code has already been used, wait for the next one")
+	}
+
+	return nil
+}
+
+func (uc *twoFactorUsecaseImpl) replaceRecoveryCodes(ctx context.Context, username string) (*dto.RecoveryCodesResponse, *helpers.Error) {
+	resp := &dto.RecoveryCodesResponse{
+		RecoveryCodes: make([]string, 0, numOfRecoveryCodes),
+	}
+	codes := make([]model.RecoveryCode, 0, numOfRecoveryCodes)
+
+	for i := 0; i < numOfRecoveryCodes; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			uc.logger.ErrorContext(ctx, err.Error())
+			return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		resp.RecoveryCodes = append(resp.RecoveryCodes, code)
+		codes = append(codes, model.RecoveryCode{
+			Username: username,
+			CodeHash: utils.HashToken(normalizeRecoveryCode(code)),
+		})
+	}
+
+	err := uc.recoveryCodeRepo.Replace(ctx, username, codes)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return resp, nil
+}
+
+func (uc *twoFactorUsecaseImpl) findUser(ctx context.Context, username string) (*model.User, *helpers.Error) {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	return user, nil
+}
+
+// generateRecoveryCode returns a code like "k7tq2-mx9ha", made of characters that are hard to mix up.
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = recoveryCodeCharset[int(b[i])%len(recoveryCodeCharset)]
+	}
+	return fmt.Sprintf("%s-%s", b[:5], b[5:]), nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
@@ -0,0 +1,191 @@
+package userusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/app/delivery/http/user/dto"
+	"goproject/internal/domain/model"
+	"goproject/internal/helpers"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// GetProfile leaves out whatever the user hid, the email is never part of it.
+func (uc *userUsecaseImpl) GetProfile(ctx context.Context, username, filesURL string) (*dto.ProfileResponse, *helpers.Error) {
+	user, err := uc.repo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	profile, err := uc.buildProfile(ctx, *user, filesURL)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return profile, nil
+}
+
+func (uc *userUsecaseImpl) buildProfile(ctx context.Context, user model.User, filesURL string) (*dto.ProfileResponse, error) {
+	links, avatar, err := uc.findProfileExtras(ctx, user, filesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := uc.postRepo.CountPublishedByOwner(ctx, user.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &dto.ProfileResponse{
+		Username:    user.Username,
+		Name:        user.Name,
+		Bio:         user.Bio,
+		Website:     user.Website,
+		SocialLinks: links,
+		Avatar:      avatar,
+		Posts:       posts,
+		JoinedAt:    user.CreatedAt,
+	}
+
+	if user.ShowLocation {
+		profile.Location = user.Location
+	}
+
+	if user.ShowFollows {
+		followers, err := uc.followRepo.CountFollowers(ctx, user.Username)
+		if err != nil {
+			return nil, err
+		}
+		following, err := uc.followRepo.CountFollowing(ctx, user.Username)
+		if err != nil {
+			return nil, err
+		}
+		profile.Followers = &followers
+		profile.Following = &following
+	}
+
+	if user.ShowActivity {
+		comments, err := uc.commentRepo.CountVisibleByCommenter(ctx, user.Username)
+		if err != nil {
+			return nil, err
+		}
+		profile.Comments = &comments
+	}
+
+	return profile, nil
+}
+
+// findProfileExtras finds what the profile keeps outside of the user's row: their social links and avatar.
+func (uc *userUsecaseImpl) findProfileExtras(ctx context.Context, user model.User, filesURL string) ([]dto.SocialLinkResponse, *dto.AvatarResponse, error) {
+	links, err := uc.repo.FindSocialLinks(ctx, user.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	linksData := make([]dto.SocialLinkResponse, 0, len(links))
+	for _, link := range links {
+		linksData = append(linksData, dto.SocialLinkResponse{
+			Label: link.Label,
+			URL:   link.URL,
+		})
+	}
+
+	if user.AvatarMediaID == nil {
+		return linksData, nil, nil
+	}
+
+	media, err := uc.mediaRepo.FindByIDAndUsername(ctx, *user.AvatarMediaID, user.Username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return linksData, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return linksData, uc.avatarResponse(*media, filesURL), nil
+}
+
+// avatarResponse is nil until the image has been processed, the upload itself is never served.
+func (uc *userUsecaseImpl) avatarResponse(media model.Media, filesURL string) *dto.AvatarResponse {
+	if media.Status != model.MediaStatusReady || len(media.Variants) == 0 {
+		return nil
+	}
+
+	avatar := new(dto.AvatarResponse)
+	srcset := make([]string, 0, len(media.Variants))
+	for _, variant := range media.Variants {
+		url, ok := uc.store.PublicURL(variant.Key)
+		if !ok {
+			url = filesURL + "/" + variant.Key
+		}
+		srcset = append(srcset, fmt.Sprintf("%s %dw", url, variant.Width))
+		// variants are sorted by width, the last one is the largest
+		avatar.URL = url
+	}
+	avatar.Srcset = strings.Join(srcset, ", ")
+
+	return avatar
+}
+
+func (uc *userUsecaseImpl) UpdateProfile(ctx context.Context, username string, data dto.UpdateProfileRequest) *helpers.Error {
+	// the avatar has to be one of the user's own uploads, one that's still being processed shows up once it's ready
+	if data.AvatarMediaID != nil {
+		media, err := uc.mediaRepo.FindByIDAndUsername(ctx, *data.AvatarMediaID, username)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("media with id %d not found", *data.AvatarMediaID))
+			}
+			uc.logger.ErrorContext(ctx, err.Error())
+			return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+		if media.Status == model.MediaStatusFailed {
+			return helpers.ErrorBuilder(http.StatusConflict, "this media couldn't be processed, upload the image again")
+		}
+	}
+
+	links := make([]model.SocialLink, 0, len(data.SocialLinks))
+	for i, link := range data.SocialLinks {
+		links = append(links, model.SocialLink{
+			Username: username,
+			Label:    link.Label,
+			URL:      link.URL,
+			Position: i,
+		})
+	}
+
+	err := uc.repo.UpdateProfile(ctx, model.User{
+		Username:      username,
+		Bio:           data.Bio,
+		Website:       data.Website,
+		Location:      data.Location,
+		AvatarMediaID: data.AvatarMediaID,
+	}, links)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
+
+func (uc *userUsecaseImpl) UpdatePrivacy(ctx context.Context, username string, data dto.UpdatePrivacyRequest) *helpers.Error {
+	err := uc.repo.UpdatePrivacy(ctx, username, model.PrivacySettings{
+		ShowLocation: *data.ShowLocation,
+		ShowFollows:  *data.ShowFollows,
+		ShowActivity: *data.ShowActivity,
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
@@ -5,34 +5,64 @@ import (
 	"errors"
 	"fmt"
 	"goproject/internal/app/delivery/http/user/dto"
+	verificationusecase "goproject/internal/app/usecase/verification"
+	"goproject/internal/domain/model"
 	"goproject/internal/domain/repository"
 	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/storage"
 	"goproject/internal/utils"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type UserUsecase interface {
-	GetUserDataByUsername(ctx context.Context, username string) (*dto.UserResponse, *helpers.Error)
-	ChangePasswordByUsername(ctx context.Context, username string, data dto.UpdatePasswordRequest) *helpers.Error
-	UpdateUserInformation(ctx context.Context, username string, data dto.UserUpdateInfoRequest) *helpers.Error
+	// GetUserDataByUsername and GetProfile take the URL the app serves files from, for the avatar's URLs.
+	GetUserDataByUsername(ctx context.Context, username, filesURL string) (*dto.UserResponse, *helpers.Error)
+	GetProfile(ctx context.Context, username, filesURL string) (*dto.ProfileResponse, *helpers.Error)
+	UpdateProfile(ctx context.Context, username string, data dto.UpdateProfileRequest) *helpers.Error
+	UpdatePrivacy(ctx context.Context, username string, data dto.UpdatePrivacyRequest) *helpers.Error
+	// ChangePasswordByUsername logs the user out everywhere but in sessionID.
+	ChangePasswordByUsername(ctx context.Context, username string, sessionID uint, data dto.UpdatePasswordRequest) *helpers.Error
+	UpdateUserInformation(ctx context.Context, username string, data dto.UserUpdateInfoRequest, verifyURL string) *helpers.Error
+	ChangeUsername(ctx context.Context, username string, data dto.UpdateUsernameRequest) *helpers.Error
 }
 
+// usernameChanges is how many times a user can rename themselves within model.UsernameCooldown, each rename keeps
+// the old name from everyone else for that long.
+const usernameChanges = 3
+
 type userUsecaseImpl struct {
-	repo   repository.UserRepository
-	logger *slog.Logger
+	repo        repository.UserRepository
+	aliasRepo   repository.UsernameAliasRepository
+	followRepo  repository.FollowRepository
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	mediaRepo   repository.MediaRepository
+	store       storage.BlobStore
+	verifier    verificationusecase.VerificationUsecase
+	db          *gorm.DB
+	logger      *slog.Logger
 }
 
-func NewUserUsecase(repository repository.UserRepository, logger *slog.Logger) UserUsecase {
+func NewUserUsecase(repository repository.UserRepository, aliasRepo repository.UsernameAliasRepository, followRepo repository.FollowRepository, postRepo repository.PostRepository, commentRepo repository.CommentRepository, mediaRepo repository.MediaRepository, store storage.BlobStore, verifier verificationusecase.VerificationUsecase, db *gorm.DB, logger *slog.Logger) UserUsecase {
 	return &userUsecaseImpl{
-		repo:   repository,
-		logger: logger,
+		repo:        repository,
+		aliasRepo:   aliasRepo,
+		followRepo:  followRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		mediaRepo:   mediaRepo,
+		store:       store,
+		verifier:    verifier,
+		db:          db,
+		logger:      logger,
 	}
 }
 
-func (uc *userUsecaseImpl) GetUserDataByUsername(ctx context.Context, username string) (*dto.UserResponse, *helpers.Error) {
+func (uc *userUsecaseImpl) GetUserDataByUsername(ctx context.Context, username, filesURL string) (*dto.UserResponse, *helpers.Error) {
 	data, err := uc.repo.FindByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -42,16 +72,36 @@ func (uc *userUsecaseImpl) GetUserDataByUsername(ctx context.Context, username s
 		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
+	links, avatar, err := uc.findProfileExtras(ctx, *data, filesURL)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return nil, helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
 	user := &dto.UserResponse{
-		Name:     data.Name,
-		Username: data.Username,
-		Email:    data.Email,
+		Name:                data.Name,
+		Username:            data.Username,
+		Email:               data.Email,
+		EmailVerified:       data.EmailVerifiedAt != nil,
+		TwoFactor:           data.TOTPEnabledAt != nil,
+		DeletionScheduledAt: data.DeletionScheduledAt,
+		Bio:                 data.Bio,
+		Website:             data.Website,
+		Location:            data.Location,
+		SocialLinks:         links,
+		AvatarMediaID:       data.AvatarMediaID,
+		Avatar:              avatar,
+		Privacy: dto.PrivacySettings{
+			ShowLocation: data.ShowLocation,
+			ShowFollows:  data.ShowFollows,
+			ShowActivity: data.ShowActivity,
+		},
 	}
 
 	return user, nil
 }
 
-func (uc *userUsecaseImpl) ChangePasswordByUsername(ctx context.Context, username string, data dto.UpdatePasswordRequest) *helpers.Error {
+func (uc *userUsecaseImpl) ChangePasswordByUsername(ctx context.Context, username string, sessionID uint, data dto.UpdatePasswordRequest) *helpers.Error {
 	user, err := uc.repo.FindByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -63,6 +113,9 @@ func (uc *userUsecaseImpl) ChangePasswordByUsername(ctx context.Context, usernam
 
 	err = utils.IsValidPassword(user.Password, data.OldPassword)
 	if err != nil {
+		if errors.Is(err, utils.ErrNoPassword) {
+			return helpers.ErrorBuilder(http.StatusConflict, "you haven't set a password yet, set one through forgot password")
+		}
 		return helpers.ErrorBuilder(http.StatusUnauthorized, "old password you provided is incorrect")
 	}
 
@@ -71,9 +124,8 @@ func (uc *userUsecaseImpl) ChangePasswordByUsername(ctx context.Context, usernam
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	user.Password = newPassword
-
-	err = uc.repo.Update(ctx, *user)
+	// a session or token of someone who learned the old password would otherwise outlive the change
+	err = uc.repo.UpdatePassword(ctx, user.Username, newPassword, sessionID)
 	if err != nil {
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
@@ -81,7 +133,7 @@ func (uc *userUsecaseImpl) ChangePasswordByUsername(ctx context.Context, usernam
 	return nil
 }
 
-func (uc *userUsecaseImpl) UpdateUserInformation(ctx context.Context, username string, data dto.UserUpdateInfoRequest) *helpers.Error {
+func (uc *userUsecaseImpl) UpdateUserInformation(ctx context.Context, username string, data dto.UserUpdateInfoRequest, verifyURL string) *helpers.Error {
 	user, err := uc.repo.FindByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -91,17 +143,94 @@ func (uc *userUsecaseImpl) UpdateUserInformation(ctx context.Context, username s
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
 
-	user.Name = data.Name
-	user.Email = data.Email
+	err = uc.repo.UpdateName(ctx, username, data.Name)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	// a new email has to be verified again before the user can write anything
+	if data.Email != user.Email {
+		err = uc.repo.UpdateEmail(ctx, username, data.Email)
+		if err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return helpers.ErrorBuilder(http.StatusConflict, "email already used")
+			}
+			uc.logger.ErrorContext(ctx, err.Error())
+			return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+		}
+
+		// the email is changed either way, a failed email can be sent again through the resend endpoint
+		user.Email = data.Email
+		user.EmailVerifiedAt = nil
+		ucErr := uc.verifier.SendVerificationEmail(ctx, *user, verifyURL)
+		if ucErr != nil {
+			uc.logger.WarnContext(ctx, "verification email not sent after changing the email", "username", username, "error", ucErr.String())
+		}
+	}
+
+	return nil
+}
+
+// ChangeUsername renames the user in a single transaction, everything referencing them follows through the database.
+// The old name is kept as an alias, so its links redirect to the new one and nobody else can take it for a while.
+func (uc *userUsecaseImpl) ChangeUsername(ctx context.Context, username string, data dto.UpdateUsernameRequest) *helpers.Error {
+	if data.Username == username {
+		return helpers.ErrorBuilder(http.StatusConflict, "that's already your username")
+	}
+
+	since := time.Now().Add(-model.UsernameCooldown)
+
+	changes, err := uc.aliasRepo.CountCreatedSince(ctx, username, since)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+	if changes >= usernameChanges {
+		return helpers.ErrorBuilder(http.StatusTooManyRequests, "you've changed your username too many times lately, try again later")
+	}
+
+	tx := uc.db.Begin()
+
+	// the user can take back one of their own old names right away
+	err = uc.aliasRepo.Claim(ctx, data.Username, username, since, tx)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, repository.ErrUsernameReserved) {
+			return helpers.ErrorBuilder(http.StatusConflict, "username already used")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
 
-	err = uc.repo.Update(ctx, *user)
+	affected, err := uc.repo.UpdateUsername(ctx, username, data.Username, tx)
 	if err != nil {
+		tx.Rollback()
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			return helpers.ErrorBuilder(http.StatusConflict, "email already used")
+			return helpers.ErrorBuilder(http.StatusConflict, "username already used")
 		}
 		uc.logger.ErrorContext(ctx, err.Error())
 		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
 	}
+	if affected == 0 {
+		tx.Rollback()
+		return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+	}
+
+	err = uc.aliasRepo.Create(ctx, model.UsernameAlias{OldUsername: username, Username: data.Username}, tx)
+	if err != nil {
+		tx.Rollback()
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	uc.logger.InfoContext(ctx, "username changed", "old_username", username, "username", data.Username)
 
 	return nil
 }
@@ -0,0 +1,145 @@
+package verificationusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goproject/internal/domain/model"
+	"goproject/internal/domain/repository"
+	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/utils"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type VerificationUsecase interface {
+	SendVerificationEmail(ctx context.Context, user model.User, verifyURL string) *helpers.Error
+	ResendVerificationEmail(ctx context.Context, username, verifyURL string) *helpers.Error
+	VerifyEmail(ctx context.Context, token string) *helpers.Error
+}
+
+const verificationTokenLifespan = 24 * time.Hour
+
+type verificationUsecaseImpl struct {
+	userRepo      repository.UserRepository
+	userTokenRepo repository.UserTokenRepository
+	mailer        mail.Mailer
+	logger        *slog.Logger
+}
+
+func NewVerificationUsecase(userRepo repository.UserRepository, userTokenRepo repository.UserTokenRepository, mailer mail.Mailer, logger *slog.Logger) VerificationUsecase {
+	return &verificationUsecaseImpl{
+		userRepo:      userRepo,
+		userTokenRepo: userTokenRepo,
+		mailer:        mailer,
+		logger:        logger,
+	}
+}
+
+// SendVerificationEmail sends a link to verifyURL that confirms the user's current email.
+// Older links stop working, so a link sent to a previous email can't verify the new one.
+func (uc *verificationUsecaseImpl) SendVerificationEmail(ctx context.Context, user model.User, verifyURL string) *helpers.Error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userTokenRepo.InvalidateAll(ctx, user.Username, model.TokenPurposeEmailVerification)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userTokenRepo.Create(ctx, model.UserToken{
+		Username:  user.Username,
+		Purpose:   model.TokenPurposeEmailVerification,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(verificationTokenLifespan),
+	})
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\r\n\r\n"+
+			"Please confirm this is the email address of your account (%s) by opening this link:\r\n\r\n"+
+			"%s?token=%s\r\n\r\n"+
+			"It expires in %d hours. You can't write posts or comments until your email is verified.\r\n",
+			user.Name, user.Username, verifyURL, token, int(verificationTokenLifespan.Hours())),
+	}
+
+	// the request's context is gone by the time the email is sent, so it can't be used here
+	go func() {
+		err := uc.mailer.Send(context.Background(), msg)
+		if err != nil {
+			uc.logger.Error(err.Error())
+		}
+	}()
+
+	return nil
+}
+
+func (uc *verificationUsecaseImpl) ResendVerificationEmail(ctx context.Context, username, verifyURL string) *helpers.Error {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusNotFound, fmt.Sprintf("%s not found", username))
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return helpers.ErrorBuilder(http.StatusConflict, "email has already been verified")
+	}
+
+	return uc.SendVerificationEmail(ctx, *user, verifyURL)
+}
+
+func (uc *verificationUsecaseImpl) VerifyEmail(ctx context.Context, token string) *helpers.Error {
+	if token == "" {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "token is required")
+	}
+
+	userToken, err := uc.userTokenRepo.FindByHash(ctx, model.TokenPurposeEmailVerification, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "invalid verification token")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	if userToken.UsedAt != nil {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "verification token has already been used")
+	}
+
+	if time.Now().After(userToken.ExpiresAt) {
+		return helpers.ErrorBuilder(http.StatusBadRequest, "verification token has expired, ask for a new one")
+	}
+
+	err = uc.userTokenRepo.Consume(ctx, *userToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenUsed) {
+			return helpers.ErrorBuilder(http.StatusBadRequest, "verification token has already been used")
+		}
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	err = uc.userRepo.MarkEmailVerified(ctx, userToken.Username)
+	if err != nil {
+		uc.logger.ErrorContext(ctx, err.Error())
+		return helpers.ErrorBuilder(http.StatusInternalServerError, "it's our fault, not yours")
+	}
+
+	return nil
+}
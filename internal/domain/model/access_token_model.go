@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// PersonalAccessToken lets scripts call the API as their owner without a password, limited to its scopes.
+// Only the hash is stored, Prefix is kept so users can tell their tokens apart.
+type PersonalAccessToken struct {
+	ID         uint   `gorm:"primaryKey"`
+	Username   string `gorm:"not null;index;type:varchar(255)"`
+	Name       string `gorm:"not null;type:varchar(100)"`
+	Prefix     string `gorm:"not null;type:varchar(12)"`
+	TokenHash  string `gorm:"not null;uniqueIndex;type:varchar(64)"`
+	Scopes     string `gorm:"not null;type:text"` // space separated
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:Username;references:Username;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+const (
+	AuditActionLoginLockout = "login.lockout"
+	AuditActionLoginUnlock  = "login.unlock"
+)
+
+// AuditLog records security related events. Username is who the event is about and Actor who caused it,
+// both are kept as plain strings so the record outlives the accounts.
+type AuditLog struct {
+	ID       uint   `gorm:"primaryKey"`
+	Action   string `gorm:"not null;type:varchar(50)"`
+	Username string `gorm:"not null;index;type:varchar(255)"`
+	Actor    string `gorm:"not null;type:varchar(255)"`
+	IP       string `gorm:"not null;type:varchar(45)"`
+
+	CreatedAt time.Time
+}
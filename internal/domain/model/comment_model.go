@@ -3,14 +3,20 @@ package model
 import "time"
 
 type Comment struct {
-	ID        uint   `gorm:"primaryKey"`
-	Commenter string `gorm:"not null;type:varchar(255)"`
-	PostID    uint   `gorm:"not null"`
-	Content   string `gorm:"type:text;type:text"`
+	ID        uint    `gorm:"primaryKey"`
+	Commenter *string `gorm:"type:varchar(255)"` // nil once the commenter deleted their account
+	PostID    uint    `gorm:"not null"`
+	ParentID  *uint   `gorm:"index"`
+	Content   string  `gorm:"type:text;type:text"`
+	// DeletedAt is set on comments that were deleted while they still had replies, they're kept as a "[deleted]" tombstone
+	DeletedAt *time.Time
+	// HiddenAt is set when a moderator hides the comment, it stays in the thread as "[hidden]"
+	HiddenAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
-	User User `gorm:"foreignKey:Commenter;references:Username"`
-	Post Post `gorm:"foreignKey:PostID; references:ID;constraint:OnDelete:CASCADE"`
+	User   User     `gorm:"foreignKey:Commenter;references:Username"`
+	Post   Post     `gorm:"foreignKey:PostID; references:ID;constraint:OnDelete:CASCADE"`
+	Parent *Comment `gorm:"foreignKey:ParentID;references:ID;constraint:OnDelete:CASCADE"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Follow means Follower gets Followee's posts in their feed.
+type Follow struct {
+	ID       uint   `gorm:"primaryKey"`
+	Follower string `gorm:"not null;uniqueIndex:idx_follower_followee;type:varchar(255)"`
+	Followee string `gorm:"not null;uniqueIndex:idx_follower_followee;index;type:varchar(255)"`
+
+	CreatedAt time.Time
+
+	FollowerUser User `gorm:"foreignKey:Follower;references:Username;constraint:OnDelete:CASCADE"`
+	FolloweeUser User `gorm:"foreignKey:Followee;references:Username;constraint:OnDelete:CASCADE"`
+}
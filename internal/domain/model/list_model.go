@@ -8,6 +8,7 @@ type List struct {
 	Slug        string `gorm:"not null;index;type:varchar(510)"`
 	Description string `gorm:"type:text"`
 	Owner       string `gorm:"not null;type:varchar(255)"`
+	IsPublic    bool   `gorm:"not null;default:false"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
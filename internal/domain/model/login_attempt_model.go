@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// LoginAttempt counts the recent failed logins of a username or an IP address, Key is prefixed with what it is,
+// e.g. "user:john" or "ip:127.0.0.1".
+type LoginAttempt struct {
+	Key          string    `gorm:"primaryKey;type:varchar(300)"`
+	Failures     int       `gorm:"not null"`
+	LastFailedAt time.Time `gorm:"not null;index"`
+	LockedUntil  *time.Time
+}
+
+func UsernameAttemptKey(username string) string {
+	return "user:" + username
+}
+
+func IPAttemptKey(ip string) string {
+	return "ip:" + ip
+}
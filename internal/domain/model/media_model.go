@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Statuses of an uploaded image going through processing.
+const (
+	MediaStatusPending    = "pending"
+	MediaStatusProcessing = "processing"
+	MediaStatusReady      = "ready"
+	MediaStatusFailed     = "failed"
+)
+
+// Media is a file uploaded by a user, e.g. an image used in a post. The upload is kept in the blob store under Key
+// until it's been processed into Variants, which are what's served, the upload itself may still carry EXIF and GPS
+// metadata. Size counts towards the owner's quota, it's the upload's size at first and the variants' afterwards.
+type Media struct {
+	ID              uint   `gorm:"primaryKey"`
+	Username        string `gorm:"not null;index;type:varchar(255)"`
+	Key             string `gorm:"not null;uniqueIndex;type:varchar(300)"`
+	Filename        string `gorm:"not null;type:varchar(255)"`
+	ContentType     string `gorm:"not null;type:varchar(100)"`
+	Size            int64  `gorm:"not null"`
+	Status          string `gorm:"not null;index;type:varchar(20);default:pending"`
+	Attempts        int    `gorm:"not null;default:0"`
+	ProcessingError string `gorm:"not null;type:varchar(255);default:''"`
+	Width           int    `gorm:"not null;default:0"`
+	Height          int    `gorm:"not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User     User           `gorm:"foreignKey:Username;references:Username;constraint:OnDelete:CASCADE"`
+	Variants []MediaVariant `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// MediaVariant is a processed copy of an uploaded image at one of the standard widths, stripped of metadata.
+type MediaVariant struct {
+	ID          uint   `gorm:"primaryKey"`
+	MediaID     uint   `gorm:"not null;index"`
+	Key         string `gorm:"not null;uniqueIndex;type:varchar(300)"`
+	Width       int    `gorm:"not null"`
+	Height      int    `gorm:"not null"`
+	ContentType string `gorm:"not null;type:varchar(100)"`
+	Size        int64  `gorm:"not null"`
+}
@@ -2,16 +2,32 @@ package model
 
 import "time"
 
+const (
+	PostStatusDraft     = "draft"
+	PostStatusScheduled = "scheduled"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
 type Post struct {
 	ID      uint   `gorm:"primaryKey"`
 	Title   string `gorm:"not null;type:varchar(255)"`
 	Slug    string `gorm:"not null;index;type:varchar(510)"`
-	Content string `gorm:"not null;type:text"`
+	Content string `gorm:"not null;type:text"` // markdown
+	// rendered from Content whenever it changes, posts rendered by an older renderer are rendered again in the background
+	ContentHTML        string `gorm:"not null;default:'';type:text"`
+	Excerpt            string `gorm:"not null;default:'';type:varchar(300)"`
+	ReadingTimeMinutes int    `gorm:"not null;default:0"`
+	RenderVersion      int    `gorm:"not null;default:0;index"`
 	// Author  string `gorm:"not null;type:varchar(255)"`
-	BlogID uint `gorm:"not null;index"`
+	BlogID      uint   `gorm:"not null;index"`
+	Status      string `gorm:"not null;default:published;index;type:varchar(20)"`
+	PublishedAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
-	Blog Blog `gorm:"foreignKey:BlogID; references:ID"`
+	Blog      Blog `gorm:"foreignKey:BlogID; references:ID"`
+	Revisions []PostRevision
+	Tags      []Tag `gorm:"many2many:post_tags"`
 }
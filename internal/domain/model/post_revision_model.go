@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// PostRevision is an immutable snapshot of a post's title and content.
+// A new one is written every time a post is created, updated or restored.
+type PostRevision struct {
+	ID           uint   `gorm:"primaryKey"`
+	PostID       uint   `gorm:"not null;uniqueIndex:idx_post_id_revision"`
+	Revision     uint   `gorm:"not null;uniqueIndex:idx_post_id_revision"`
+	Title        string `gorm:"not null;type:varchar(255)"`
+	Content      string `gorm:"not null;type:text"`
+	RestoredFrom *uint
+
+	CreatedAt time.Time
+
+	Post Post `gorm:"foreignKey:PostID;references:ID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// RecoveryCode lets a user with 2FA log in without their authenticator, each one works once.
+type RecoveryCode struct {
+	ID       uint   `gorm:"primaryKey"`
+	Username string `gorm:"not null;uniqueIndex:idx_username_code_hash;type:varchar(255)"`
+	CodeHash string `gorm:"not null;uniqueIndex:idx_username_code_hash;type:varchar(64)"`
+	UsedAt   *time.Time
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:Username;references:Username;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Report is a user flagging a post, or a comment on it when CommentID is set, for moderators to look at.
+type Report struct {
+	ID         uint    `gorm:"primaryKey"`
+	Reporter   string  `gorm:"not null;type:varchar(255)"`
+	PostID     uint    `gorm:"not null;index"`
+	CommentID  *uint   `gorm:"index"`
+	Reason     string  `gorm:"not null;type:text"`
+	ResolvedBy *string `gorm:"type:varchar(255)"`
+	ResolvedAt *time.Time
+
+	CreatedAt time.Time
+
+	User    User     `gorm:"foreignKey:Reporter;references:Username;constraint:OnDelete:CASCADE"`
+	Post    Post     `gorm:"foreignKey:PostID;references:ID;constraint:OnDelete:CASCADE"`
+	Comment *Comment `gorm:"foreignKey:CommentID;references:ID;constraint:OnDelete:CASCADE"`
+}
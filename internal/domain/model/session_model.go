@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type Session struct {
+	ID        uint   `gorm:"primaryKey"`
+	Username  string `gorm:"not null;index;type:varchar(255)"`
+	RevokedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User          User `gorm:"foreignKey:Username;references:Username"`
+	RefreshTokens []RefreshToken
+}
+
+// RefreshToken is a single link in a session's rotation chain.
+// Only the hash of the token is stored, the raw value is handed to the client once.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	SessionID uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"not null;uniqueIndex;type:varchar(64)"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+
+	CreatedAt time.Time
+
+	Session Session `gorm:"foreignKey:SessionID;references:ID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type Tag struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"not null;type:varchar(50)"`
+	Slug string `gorm:"not null;uniqueIndex;type:varchar(50)"`
+
+	CreatedAt time.Time
+
+	Posts []Post `gorm:"many2many:post_tags"`
+}
+
+type PostTag struct {
+	PostID uint `gorm:"uniqueIndex:idx_post_id_tag_id"`
+	TagID  uint `gorm:"uniqueIndex:idx_post_id_tag_id;index"`
+
+	Post Post `gorm:"foreignKey:PostID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Tag  Tag  `gorm:"foreignKey:TagID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (PostTag) TableName() string {
+	return "post_tags"
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// UserIdentity links a user to their account at an OpenID Connect provider, which is identified by the issuer and
+// the subject, emails may change.
+type UserIdentity struct {
+	ID       uint   `gorm:"primaryKey"`
+	Username string `gorm:"not null;index;type:varchar(255)"`
+	Issuer   string `gorm:"not null;uniqueIndex:idx_user_identities_issuer_subject;type:varchar(255)"`
+	Subject  string `gorm:"not null;uniqueIndex:idx_user_identities_issuer_subject;type:varchar(255)"`
+	Email    string `gorm:"not null;type:varchar(255)"` // as the provider knew it when the identity was linked
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:Username;references:Username;constraint:OnDelete:CASCADE"`
+}
+
+// OIDCAuthRequest is a login started at an OpenID Connect provider, found again by its state when the provider sends
+// the user back. It holds the PKCE verifier and the nonce, which never leave the server.
+type OIDCAuthRequest struct {
+	ID           uint      `gorm:"primaryKey"`
+	StateHash    string    `gorm:"not null;uniqueIndex;type:varchar(64)"`
+	CodeVerifier string    `gorm:"not null;type:varchar(128)"`
+	Nonce        string    `gorm:"not null;type:varchar(128)"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	UsedAt       *time.Time
+
+	CreatedAt time.Time
+}
+
+func (OIDCAuthRequest) TableName() string {
+	return "oidc_auth_requests"
+}
@@ -2,13 +2,59 @@ package model
 
 import "time"
 
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
 type User struct {
 	ID       uint   `gorm:"primaryKey"`
 	Email    string `gorm:"not null;unique;default:null;type:varchar(255)"`
 	Username string `gorm:"not null;uniqueIndex;type:varchar(255)"`
 	Name     string `gorm:"not null;default:null;type:varchar(255)"`
-	Password []byte `gorm:"not null;default:null;type:bytea"`
+	// Password is nil for accounts created through a magic link or single sign-on, until the user sets one
+	Password []byte `gorm:"type:bytea"`
+	Role     string `gorm:"not null;default:user;type:varchar(20)"`
+	// EmailVerifiedAt stays nil until the user follows the link sent to their email, and is reset when the email changes
+	EmailVerifiedAt *time.Time
+	// TOTPSecret is set as soon as the user starts enrolling in 2FA, but 2FA is only on once TOTPEnabledAt is set
+	TOTPSecret    *string `gorm:"type:varchar(64)"`
+	TOTPEnabledAt *time.Time
+	// TOTPLastStep is the time step of the last accepted code, so the same code can't be used twice
+	TOTPLastStep int64 `gorm:"not null;default:0"`
+	// SuspendedAt is set while the user is suspended, they can't log in until an admin lifts it
+	SuspendedAt *time.Time
+	// DeletionScheduledAt is when the account will be deleted, set while the user can still change their mind
+	DeletionScheduledAt *time.Time
+
+	// the public profile, the avatar is one of the user's uploads and is cleared when the upload is deleted
+	Bio           string `gorm:"not null;default:'';type:text"`
+	Website       string `gorm:"not null;default:'';type:varchar(255)"`
+	Location      string `gorm:"not null;default:'';type:varchar(100)"`
+	AvatarMediaID *uint
+	PrivacySettings
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// PrivacySettings decide what the public profile shows besides the user's name, bio, links and published posts.
+type PrivacySettings struct {
+	ShowLocation bool `gorm:"not null;default:true"`
+	// ShowFollows covers the follower and following counts as well as the lists themselves
+	ShowFollows bool `gorm:"not null;default:true"`
+	// ShowActivity covers how many comments the user wrote
+	ShowActivity bool `gorm:"not null;default:true"`
+}
+
+// SocialLink is a link to the user's account elsewhere shown on their profile, in the order of Position.
+type SocialLink struct {
+	ID       uint   `gorm:"primaryKey"`
+	Username string `gorm:"not null;index;type:varchar(255)"`
+	Label    string `gorm:"not null;type:varchar(50)"`
+	URL      string `gorm:"not null;type:varchar(255)"`
+	Position int    `gorm:"not null;default:0"`
+
+	User User `gorm:"foreignKey:Username;references:Username;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
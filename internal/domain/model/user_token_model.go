@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposeTwoFactorLogin    = "two_factor_login"
+	TokenPurposeAccountUnlock     = "account_unlock"
+	TokenPurposeMagicLogin        = "magic_login"
+)
+
+// UserToken is a single-use token sent to a user out of band, e.g. by email.
+// Like refresh tokens, only its hash is stored.
+type UserToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	Username  string    `gorm:"not null;index;type:varchar(255)"`
+	Purpose   string    `gorm:"not null;type:varchar(30)"`
+	TokenHash string    `gorm:"not null;uniqueIndex;type:varchar(64)"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:Username;references:Username;constraint:OnDelete:CASCADE"`
+}
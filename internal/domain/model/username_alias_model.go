@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// UsernameCooldown is how long a username stays reserved after its owner renamed themselves, so nobody else can take
+// over their links. Once it's over the name can be registered again, which ends its redirects.
+const UsernameCooldown = 90 * 24 * time.Hour
+
+// UsernameAlias is a name the user went by before, requests for it are redirected to their current name.
+type UsernameAlias struct {
+	ID          uint   `gorm:"primaryKey"`
+	OldUsername string `gorm:"not null;uniqueIndex;type:varchar(255)"`
+	Username    string `gorm:"not null;index;type:varchar(255)"`
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:Username;references:Username;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,56 @@
+// Package policy decides who is allowed to do what, so handlers and usecases don't have to hard-code it.
+package policy
+
+import (
+	"goproject/internal/domain/model"
+	"slices"
+)
+
+type Action string
+
+const (
+	SuspendUser      Action = "user:suspend"
+	UnlockUser       Action = "user:unlock"
+	ChangeRole       Action = "user:change_role"
+	RemoveAnyPost    Action = "post:remove_any"
+	RemoveAnyComment Action = "comment:remove_any"
+	HideComment      Action = "comment:hide"
+	ViewReports      Action = "report:view"
+	ResolveReport    Action = "report:resolve"
+)
+
+// grants lists what each role can do on top of what every user can do with their own content.
+var grants = map[string][]Action{
+	model.RoleUser:      {},
+	model.RoleModerator: {HideComment, ViewReports, ResolveReport},
+	model.RoleAdmin:     {SuspendUser, UnlockUser, ChangeRole, RemoveAnyPost, RemoveAnyComment, HideComment, ViewReports, ResolveReport},
+}
+
+// Actor is the user doing something.
+type Actor struct {
+	Username string
+	Role     string
+}
+
+func IsValidRole(role string) bool {
+	_, ok := grants[role]
+	return ok
+}
+
+func Can(role string, action Action) bool {
+	return slices.Contains(grants[role], action)
+}
+
+// CanDeleteComment allows the commenter, the owner of the blog the comment is on, and admins.
+func CanDeleteComment(actor Actor, comment model.Comment, blogOwner string) bool {
+	return isCommenter(actor, comment) || blogOwner == actor.Username || Can(actor.Role, RemoveAnyComment)
+}
+
+// CanEditComment only allows the commenter, nobody gets to put words in someone else's mouth.
+func CanEditComment(actor Actor, comment model.Comment) bool {
+	return isCommenter(actor, comment)
+}
+
+func isCommenter(actor Actor, comment model.Comment) bool {
+	return comment.Commenter != nil && *comment.Commenter == actor.Username
+}
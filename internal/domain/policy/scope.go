@@ -0,0 +1,18 @@
+package policy
+
+// Scope is what a personal access token is allowed to do. Login sessions aren't limited by scopes.
+type Scope string
+
+const (
+	ScopeProfileRead   Scope = "profile:read"
+	ScopeBlogWrite     Scope = "blog:write"
+	ScopePostsRead     Scope = "posts:read"
+	ScopePostsWrite    Scope = "posts:write"
+	ScopeCommentsRead  Scope = "comments:read"
+	ScopeCommentsWrite Scope = "comments:write"
+	ScopeListsRead     Scope = "lists:read"
+	ScopeListsWrite    Scope = "lists:write"
+	ScopeFollowsWrite  Scope = "follows:write"
+	ScopeMediaRead     Scope = "media:read"
+	ScopeMediaWrite    Scope = "media:write"
+)
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type AccessTokenRepository interface {
+	Create(ctx context.Context, data model.PersonalAccessToken) (*model.PersonalAccessToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (*model.PersonalAccessToken, error)
+	FindByUsername(ctx context.Context, username string, page PageRequest) ([]model.PersonalAccessToken, error)
+	Revoke(ctx context.Context, username string, tokenID uint) (int64, error)
+	MarkUsed(ctx context.Context, tokenID uint) error
+}
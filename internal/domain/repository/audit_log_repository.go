@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, data model.AuditLog) error
+}
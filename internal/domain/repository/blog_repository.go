@@ -11,4 +11,5 @@ type BlogRepository interface {
 	Create(ctx context.Context, data model.Blog, tx *gorm.DB) error
 	Update(ctx context.Context, data model.Blog) error
 	FindByOwner(ctx context.Context, username string) (*model.Blog, error)
+	CountPublishedPosts(ctx context.Context, blogID uint) (int64, error)
 }
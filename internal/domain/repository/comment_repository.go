@@ -3,13 +3,19 @@ package repository
 import (
 	"context"
 	"goproject/internal/domain/model"
+	"time"
 )
 
 type CommentRepository interface {
 	Create(ctx context.Context, data model.Comment) (uint, error)
-	FindCommentByUsername(ctx context.Context, username string) ([]model.Comment, error)
-	FindCommentByPostID(ctx context.Context, PostID uint) ([]model.Comment, error)
+	FindCommentByUsername(ctx context.Context, username string, page PageRequest) ([]model.Comment, error)
+	FindAllByCommenter(ctx context.Context, username string) ([]model.Comment, error)
+	CountVisibleByCommenter(ctx context.Context, username string) (int64, error)
+	FindRootCommentsByPostID(ctx context.Context, PostID uint, page PageRequest) ([]model.Comment, error)
+	FindRepliesByRootIDs(ctx context.Context, rootIDs []uint, maxDepth int) ([]model.Comment, error)
+	CountRepliesByParentIDs(ctx context.Context, parentIDs []uint) (map[uint]int64, error)
 	Delete(ctx context.Context, data model.Comment) error
+	SetHidden(ctx context.Context, data model.Comment, hiddenAt *time.Time) error
 	FindCommentByID(ctx context.Context, commentID uint) (*model.Comment, error)
 	Update(ctx context.Context, data model.Comment) error
 }
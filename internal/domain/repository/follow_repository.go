@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type FollowRepository interface {
+	Create(ctx context.Context, data model.Follow) error
+	Delete(ctx context.Context, follower, followee string) (int64, error)
+	FindFollowers(ctx context.Context, username string, page PageRequest) ([]model.Follow, error)
+	FindFollowing(ctx context.Context, username string, page PageRequest) ([]model.Follow, error)
+	CountFollowers(ctx context.Context, username string) (int64, error)
+	CountFollowing(ctx context.Context, username string) (int64, error)
+}
@@ -9,9 +9,13 @@ type ListRepository interface {
 	Create(ctx context.Context, data model.List) error
 	AddPostToList(ctx context.Context, postData model.Post, listData model.List) error
 	Update(ctx context.Context, data model.List) error
-	FindListsByOwner(ctx context.Context, username string) ([]model.List, error)
+	FindListsByOwner(ctx context.Context, username string, page PageRequest) ([]model.List, error)
+	// CountPublishedPostsByListIDs counts the published posts of each list, lists without any are left out.
+	CountPublishedPostsByListIDs(ctx context.Context, listIDs []uint) (map[uint]int64, error)
+	FindAllByOwner(ctx context.Context, username string) ([]model.List, error)
 	FindListByOwnerAndListSlug(ctx context.Context, username, ListSlug string) (*model.List, error)
-	FindPostsInAListByListSlug(ctx context.Context, username, ListSlug string) (*model.List, error)
+	FindPostsInAListByListSlug(ctx context.Context, username, ListSlug string, page PageRequest) (*model.List, error)
+	FindPostInListBySlug(ctx context.Context, listID uint, postSlug string) (*model.Post, error)
 	RemovePostFromList(ctx context.Context, postData model.Post, listData model.List) error
 	Delete(ctx context.Context, data model.List) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"time"
+)
+
+// LoginAttemptRepository tracks failed logins, it's implemented both in memory and in the database.
+type LoginAttemptRepository interface {
+	// Find returns a zero LoginAttempt when nothing has been recorded for key.
+	Find(ctx context.Context, key string) (model.LoginAttempt, error)
+	// RecordFailure adds a failure to key, failures older than window are forgotten first.
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (model.LoginAttempt, error)
+	// Lock locks key out until the given time and forgets its failures, so once the lockout is over it takes as many
+	// failures as the first time to be locked out again.
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	DeleteStale(ctx context.Context, before time.Time) error
+}
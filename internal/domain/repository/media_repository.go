@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"time"
+)
+
+type MediaRepository interface {
+	Create(ctx context.Context, data model.Media) (*model.Media, error)
+	FindByUsername(ctx context.Context, username string, page PageRequest) ([]model.Media, error)
+	FindAllByUsername(ctx context.Context, username string) ([]model.Media, error)
+	FindByIDAndUsername(ctx context.Context, id uint, username string) (*model.Media, error)
+	FindVariantByKey(ctx context.Context, key string) (*model.MediaVariant, error)
+	TotalSizeByUsername(ctx context.Context, username string) (int64, error)
+	Delete(ctx context.Context, data model.Media) error
+	// ClaimPending marks up to limit pending media as processing and returns them, media left processing since
+	// before staleBefore are claimed again. Concurrent callers never get the same media.
+	ClaimPending(ctx context.Context, staleBefore time.Time, limit int) ([]model.Media, error)
+	SaveProcessed(ctx context.Context, data model.Media, variants []model.MediaVariant) error
+	UpdateStatus(ctx context.Context, id uint, status, processingError string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+	"time"
+)
+
+type OIDCAuthRequestRepository interface {
+	Create(ctx context.Context, data model.OIDCAuthRequest) error
+	// Consume marks the request as used and returns it, failing with gorm.ErrRecordNotFound when there's no unused
+	// request with the state, so each one can only finish a single login.
+	Consume(ctx context.Context, stateHash string) (*model.OIDCAuthRequest, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cursor points at a single row of a collection ordered on (created_at, id).
+// Published posts are ordered on (published_at, id) instead, so their cursors carry published_at.
+// Search results are ranked instead, so their cursors carry the rank in place of created_at.
+type Cursor struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Rank        float64    `json:"rank,omitempty"`
+	ID          uint       `json:"id"`
+}
+
+// PageRequest asks for up to Limit rows after or before a cursor. Without a cursor the first page is returned.
+type PageRequest struct {
+	Limit  int
+	After  *Cursor
+	Before *Cursor
+}
+
+// Scope orders the query from newest to oldest on (created_at, id) of the given table and limits it to the requested page.
+// One extra row is fetched, so the caller can tell whether there is another page.
+// Rows of a "before" page come back oldest first and have to be reversed by the caller.
+func (p PageRequest) Scope(table string) func(db *gorm.DB) *gorm.DB {
+	return p.scope(table, "created_at", func(cursor *Cursor) any {
+		return cursor.CreatedAt
+	})
+}
+
+// PublishedScope is Scope on (published_at, id), for collections of published posts, which are in the order they
+// came out rather than the order they were written.
+func (p PageRequest) PublishedScope(table string) func(db *gorm.DB) *gorm.DB {
+	return p.scope(table, "published_at", func(cursor *Cursor) any {
+		return cursor.PublishedAt
+	})
+}
+
+func (p PageRequest) scope(table, column string, valueOf func(cursor *Cursor) any) func(db *gorm.DB) *gorm.DB {
+	ordered := fmt.Sprintf("%s.%s", table, column)
+	id := fmt.Sprintf("%s.id", table)
+
+	return func(db *gorm.DB) *gorm.DB {
+		switch {
+		case p.After != nil:
+			db = db.Where(fmt.Sprintf("(%s, %s) < (?, ?)", ordered, id), valueOf(p.After), p.After.ID).
+				Order(fmt.Sprintf("%s DESC, %s DESC", ordered, id))
+		case p.Before != nil:
+			db = db.Where(fmt.Sprintf("(%s, %s) > (?, ?)", ordered, id), valueOf(p.Before), p.Before.ID).
+				Order(fmt.Sprintf("%s ASC, %s ASC", ordered, id))
+		default:
+			db = db.Order(fmt.Sprintf("%s DESC, %s DESC", ordered, id))
+		}
+
+		return db.Limit(p.Limit + 1)
+	}
+}
@@ -3,12 +3,31 @@ package repository
 import (
 	"context"
 	"goproject/internal/domain/model"
+	"time"
 )
 
+type PostSearchResult struct {
+	Post    model.Post
+	Rank    float64
+	Snippet string
+}
+
 type PostRepository interface {
 	Create(ctx context.Context, data model.Post) error
-	FindByBlogID(ctx context.Context, blogID uint) ([]model.Post, error)
-	Update(ctx context.Context, data model.Post) error
+	FindByBlogID(ctx context.Context, blogID uint, tag string, page PageRequest) ([]model.Post, error)
+	FindAllByBlogID(ctx context.Context, blogID uint) ([]model.Post, error)
+	FindPublishedByBlogID(ctx context.Context, blogID uint, tag string, page PageRequest) ([]model.Post, error)
+	FindPublishedByTagID(ctx context.Context, tagID uint, page PageRequest) ([]model.Post, error)
+	FindFeed(ctx context.Context, username string, page PageRequest) ([]model.Post, error)
+	// Update replaces the post's tags too, unless tags is nil.
+	Update(ctx context.Context, data model.Post, revision model.PostRevision, tags []model.Tag) error
 	FindBySlugAndOwner(ctx context.Context, slug, owner string) (*model.Post, error)
+	FindPublishedBySlugAndOwner(ctx context.Context, slug, owner string) (*model.Post, error)
+	CountPublishedByOwner(ctx context.Context, owner string) (int64, error)
 	Delete(ctx context.Context, data model.Post) error
+	PublishScheduled(ctx context.Context, now time.Time) (int64, error)
+	// FindRenderedBefore returns up to limit posts rendered before version with an ID above afterID, by ID.
+	FindRenderedBefore(ctx context.Context, version int, afterID uint, limit int) ([]model.Post, error)
+	UpdateRendered(ctx context.Context, data model.Post) error
+	Search(ctx context.Context, query, owner string, page PageRequest) ([]PostSearchResult, error)
 }
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type PostRevisionRepository interface {
+	FindByPostID(ctx context.Context, postID uint, page PageRequest) ([]model.PostRevision, error)
+	FindByPostIDAndRevision(ctx context.Context, postID, revision uint) (*model.PostRevision, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type RecoveryCodeRepository interface {
+	Replace(ctx context.Context, username string, codes []model.RecoveryCode) error
+	Consume(ctx context.Context, username, codeHash string) (int64, error)
+	DeleteByUsername(ctx context.Context, username string) error
+}
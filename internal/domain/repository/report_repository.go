@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type ReportRepository interface {
+	Create(ctx context.Context, data model.Report) (uint, error)
+	FindByID(ctx context.Context, reportID uint) (*model.Report, error)
+	FindAll(ctx context.Context, resolved bool, page PageRequest) ([]model.Report, error)
+	Resolve(ctx context.Context, data model.Report, resolvedBy string) error
+}
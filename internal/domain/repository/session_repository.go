@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"goproject/internal/domain/model"
+)
+
+var ErrRefreshTokenUsed = errors.New("refresh token has already been used")
+
+type SessionRepository interface {
+	Create(ctx context.Context, data model.Session) (uint, error)
+	FindByID(ctx context.Context, sessionID uint) (*model.Session, error)
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, oldToken model.RefreshToken, newToken model.RefreshToken) error
+	Revoke(ctx context.Context, sessionID uint) error
+	RevokeAllByUsername(ctx context.Context, username string) error
+}
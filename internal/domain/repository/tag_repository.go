@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+)
+
+type TagCount struct {
+	Name       string
+	Slug       string
+	NumOfPosts int64
+}
+
+type TagRepository interface {
+	FindBySlug(ctx context.Context, slug string) (*model.Tag, error)
+	CountPublishedPostsByBlogID(ctx context.Context, blogID uint) ([]TagCount, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"goproject/internal/domain/model"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, data model.UserIdentity, tx *gorm.DB) error
+	FindByIssuerAndSubject(ctx context.Context, issuer, subject string) (*model.UserIdentity, error)
+}
@@ -3,12 +3,40 @@ package repository
 import (
 	"context"
 	"goproject/internal/domain/model"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, data model.User, tx *gorm.DB) error
-	Update(ctx context.Context, data model.User) error
+	UpdateName(ctx context.Context, username, name string) error
 	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdateEmail(ctx context.Context, username, email string) error
+	// UpdatePassword sets the password and revokes the user's access tokens and sessions, which were made with the old
+	// one, except for the session keepSessionID the change was made from.
+	UpdatePassword(ctx context.Context, username string, password []byte, keepSessionID uint) error
+	// ResetPassword uses up the reset token and sets the password, revoking the user's sessions and access tokens, all
+	// at once. It fails with ErrUserTokenUsed when the token was used in the meantime.
+	ResetPassword(ctx context.Context, token model.UserToken, password []byte) error
+	// UpdateProfile saves the user's bio, website, location and avatar, and replaces their social links.
+	UpdateProfile(ctx context.Context, data model.User, links []model.SocialLink) error
+	UpdatePrivacy(ctx context.Context, username string, settings model.PrivacySettings) error
+	FindSocialLinks(ctx context.Context, username string) ([]model.SocialLink, error)
+	// UpdateUsername renames the user, the database renames every reference to them along with it. Audit logs and
+	// the login attempts kept in the database, which aren't tied to the user by a foreign key, are renamed too.
+	UpdateUsername(ctx context.Context, username, newUsername string, tx *gorm.DB) (int64, error)
+	MarkEmailVerified(ctx context.Context, username string) error
+	SetTOTPSecret(ctx context.Context, username, secret string) error
+	EnableTOTP(ctx context.Context, username string) error
+	DisableTOTP(ctx context.Context, username string) error
+	UseTOTPStep(ctx context.Context, username string, step int64) (int64, error)
+	UpdateRole(ctx context.Context, username, role string) (int64, error)
+	UpdateSuspension(ctx context.Context, username string, suspendedAt *time.Time) (int64, error)
+	ScheduleDeletion(ctx context.Context, username string, deleteAt *time.Time) (int64, error)
+	FindDueForDeletion(ctx context.Context, now time.Time, limit int) ([]model.User, error)
+	// DeleteScheduled deletes the user and everything they own, as long as their deletion is still due at now,
+	// otherwise it returns gorm.ErrRecordNotFound.
+	DeleteScheduled(ctx context.Context, username string, now time.Time) error
 }
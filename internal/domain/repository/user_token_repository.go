@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"goproject/internal/domain/model"
+	"time"
+)
+
+var ErrUserTokenUsed = errors.New("token has already been used")
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, data model.UserToken) error
+	FindByHash(ctx context.Context, purpose, tokenHash string) (*model.UserToken, error)
+	Consume(ctx context.Context, data model.UserToken) error
+	InvalidateAll(ctx context.Context, username, purpose string) error
+	CountCreatedSince(ctx context.Context, username, purpose string, since time.Time) (int64, error)
+}
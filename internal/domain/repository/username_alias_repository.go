@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"goproject/internal/domain/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrUsernameReserved = errors.New("username is reserved")
+
+type UsernameAliasRepository interface {
+	Create(ctx context.Context, data model.UsernameAlias, tx *gorm.DB) error
+	FindByOldUsername(ctx context.Context, oldUsername string) (*model.UsernameAlias, error)
+	CountCreatedSince(ctx context.Context, username string, since time.Time) (int64, error)
+	// Claim frees the username for claimant, failing with ErrUsernameReserved when it's the alias of someone else
+	// created after since. An empty claimant is a new user.
+	Claim(ctx context.Context, username, claimant string, since time.Time, tx *gorm.DB) error
+}
@@ -19,10 +19,18 @@ type ResponseWithData struct {
 	Data    any    `json:"data"`
 }
 
+type ResponseWithPagination struct {
+	Success    bool       `json:"success"`
+	Message    string     `json:"message"`
+	Data       any        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
 type MyListResponse struct {
 	Slug        string `json:"list_slug"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
 	NumOfPosts  int    `json:"num_of_posts"`
 }
 
@@ -30,5 +38,6 @@ type PostsInMyListResponse struct {
 	Slug        string             `json:"list_slug"`
 	Name        string             `json:"name"`
 	Description string             `json:"description"`
+	IsPublic    bool               `json:"is_public"`
 	Posts       []dto.PostResponse `json:"posts"`
 }
@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"goproject/internal/domain/repository"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type Pagination struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+func EncodeCursor(cursor repository.Cursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*repository.Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	cursor := new(repository.Cursor)
+	err = json.Unmarshal(b, cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return cursor, nil
+}
+
+// ParsePageRequest reads the limit, after and before query params.
+func ParsePageRequest(c *gin.Context) (repository.PageRequest, error) {
+	page := repository.PageRequest{
+		Limit: defaultPageLimit,
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxPageLimit {
+			return page, errors.New("limit must be an integer between 1 and 100")
+		}
+		page.Limit = n
+	}
+
+	after, before := c.Query("after"), c.Query("before")
+	if after != "" && before != "" {
+		return page, errors.New("after and before can't be used together")
+	}
+
+	var err error
+	if after != "" {
+		page.After, err = DecodeCursor(after)
+	}
+	if before != "" {
+		page.Before, err = DecodeCursor(before)
+	}
+
+	return page, err
+}
+
+// ParsePublishedPageRequest is ParsePageRequest for collections ordered by repository.PageRequest.PublishedScope.
+// Their cursors have to carry published_at, one from another collection would otherwise just return an empty page.
+func ParsePublishedPageRequest(c *gin.Context) (repository.PageRequest, error) {
+	page, err := ParsePageRequest(c)
+	if err != nil {
+		return page, err
+	}
+
+	if (page.After != nil && page.After.PublishedAt == nil) || (page.Before != nil && page.Before.PublishedAt == nil) {
+		return page, errors.New("invalid cursor")
+	}
+
+	return page, nil
+}
+
+// BuildPage trims the extra row fetched by repository.PageRequest.Scope, puts the rows back
+// in newest-to-oldest order and builds the cursors of the neighbouring pages.
+func BuildPage[T any](items []T, page repository.PageRequest, cursorOf func(T) repository.Cursor) ([]T, *Pagination) {
+	hasMore := len(items) > page.Limit
+	if hasMore {
+		items = items[:page.Limit]
+	}
+
+	hasNext, hasPrev := hasMore, page.After != nil
+	if page.Before != nil {
+		slices.Reverse(items)
+		hasNext, hasPrev = true, hasMore
+	}
+
+	pagination := new(Pagination)
+	if len(items) == 0 {
+		return items, pagination
+	}
+
+	if hasNext {
+		pagination.Next = EncodeCursor(cursorOf(items[len(items)-1]))
+	}
+	if hasPrev {
+		pagination.Prev = EncodeCursor(cursorOf(items[0]))
+	}
+
+	return items, pagination
+}
@@ -0,0 +1,207 @@
+package helpers
+
+import (
+	"goproject/internal/domain/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	published := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]repository.Cursor{
+		"created":   {CreatedAt: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), ID: 42},
+		"published": {CreatedAt: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), PublishedAt: &published, ID: 7},
+		"rank":      {CreatedAt: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), Rank: 0.5, ID: 1},
+	}
+
+	for name, cursor := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := DecodeCursor(EncodeCursor(cursor))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.CreatedAt.Equal(cursor.CreatedAt) || got.ID != cursor.ID || got.Rank != cursor.Rank {
+				t.Errorf("DecodeCursor(EncodeCursor()) = %+v, want %+v", got, cursor)
+			}
+			if (got.PublishedAt == nil) != (cursor.PublishedAt == nil) ||
+				(got.PublishedAt != nil && !got.PublishedAt.Equal(*cursor.PublishedAt)) {
+				t.Errorf("DecodeCursor(EncodeCursor()).PublishedAt = %v, want %v", got.PublishedAt, cursor.PublishedAt)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, s := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := DecodeCursor(s); err == nil {
+			t.Errorf("DecodeCursor(%q) error = nil, want invalid cursor", s)
+		}
+	}
+}
+
+func testContext(query string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return c
+}
+
+func TestParsePageRequest(t *testing.T) {
+	cursor := EncodeCursor(repository.Cursor{CreatedAt: time.Now(), ID: 1})
+
+	tests := []struct {
+		query     string
+		limit     int
+		after     bool
+		before    bool
+		wantError bool
+	}{
+		{query: "", limit: defaultPageLimit},
+		{query: "limit=5", limit: 5},
+		{query: "limit=100", limit: 100},
+		{query: "limit=0", wantError: true},
+		{query: "limit=101", wantError: true},
+		{query: "limit=abc", wantError: true},
+		{query: "after=" + cursor, limit: defaultPageLimit, after: true},
+		{query: "before=" + cursor, limit: defaultPageLimit, before: true},
+		{query: "after=" + cursor + "&before=" + cursor, wantError: true},
+		{query: "after=garbage!", wantError: true},
+	}
+
+	for _, tt := range tests {
+		page, err := ParsePageRequest(testContext(tt.query))
+		if tt.wantError {
+			if err == nil {
+				t.Errorf("ParsePageRequest(%q) error = nil, want an error", tt.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePageRequest(%q) error = %v, want nil", tt.query, err)
+			continue
+		}
+		if page.Limit != tt.limit || (page.After != nil) != tt.after || (page.Before != nil) != tt.before {
+			t.Errorf("ParsePageRequest(%q) = %+v, want limit %d, after %v, before %v", tt.query, page, tt.limit, tt.after, tt.before)
+		}
+	}
+}
+
+func TestParsePublishedPageRequest(t *testing.T) {
+	published := time.Now()
+	withPublished := EncodeCursor(repository.Cursor{CreatedAt: time.Now(), PublishedAt: &published, ID: 1})
+	withoutPublished := EncodeCursor(repository.Cursor{CreatedAt: time.Now(), ID: 1})
+
+	tests := map[string]bool{
+		"":                           false,
+		"after=" + withPublished:     false,
+		"before=" + withPublished:    false,
+		"after=" + withoutPublished:  true,
+		"before=" + withoutPublished: true,
+	}
+
+	for query, wantError := range tests {
+		_, err := ParsePublishedPageRequest(testContext(query))
+		if (err != nil) != wantError {
+			t.Errorf("ParsePublishedPageRequest(%q) error = %v, want error %v", query, err, wantError)
+		}
+	}
+}
+
+func TestBuildPage(t *testing.T) {
+	cursorOf := func(id uint) repository.Cursor {
+		return repository.Cursor{ID: id}
+	}
+	cursor := &repository.Cursor{ID: 100}
+
+	tests := []struct {
+		name     string
+		items    []uint
+		page     repository.PageRequest
+		want     []uint
+		wantNext uint
+		wantPrev uint
+	}{
+		{
+			name:  "empty",
+			items: []uint{},
+			page:  repository.PageRequest{Limit: 2},
+			want:  []uint{},
+		},
+		{
+			name:  "first and only page",
+			items: []uint{3, 2},
+			page:  repository.PageRequest{Limit: 2},
+			want:  []uint{3, 2},
+		},
+		{
+			name:     "first page with more",
+			items:    []uint{5, 4, 3},
+			page:     repository.PageRequest{Limit: 2},
+			want:     []uint{5, 4},
+			wantNext: 4,
+		},
+		{
+			name:     "after with more",
+			items:    []uint{5, 4, 3},
+			page:     repository.PageRequest{Limit: 2, After: cursor},
+			want:     []uint{5, 4},
+			wantNext: 4,
+			wantPrev: 5,
+		},
+		{
+			name:     "after on the last page",
+			items:    []uint{2},
+			page:     repository.PageRequest{Limit: 2, After: cursor},
+			want:     []uint{2},
+			wantPrev: 2,
+		},
+		{
+			name:     "before with more",
+			items:    []uint{6, 7, 8},
+			page:     repository.PageRequest{Limit: 2, Before: cursor},
+			want:     []uint{7, 6},
+			wantNext: 6,
+			wantPrev: 7,
+		},
+		{
+			name:     "before on the first page",
+			items:    []uint{6, 7},
+			page:     repository.PageRequest{Limit: 2, Before: cursor},
+			want:     []uint{7, 6},
+			wantNext: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pagination := BuildPage(tt.items, tt.page, cursorOf)
+			if len(got) != len(tt.want) {
+				t.Fatalf("BuildPage() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("BuildPage() = %v, want %v", got, tt.want)
+				}
+			}
+
+			if want := encodeID(tt.wantNext); pagination.Next != want {
+				t.Errorf("BuildPage() next = %q, want %q", pagination.Next, want)
+			}
+			if want := encodeID(tt.wantPrev); pagination.Prev != want {
+				t.Errorf("BuildPage() prev = %q, want %q", pagination.Prev, want)
+			}
+		})
+	}
+}
+
+// encodeID is the cursor BuildPage is expected to return for id, 0 meaning no cursor
+func encodeID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return EncodeCursor(repository.Cursor{ID: id})
+}
@@ -3,13 +3,25 @@ package helpers
 import "github.com/gin-gonic/gin"
 
 type Response struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Error   any    `json:"errors,omitempty"`
-	Data    any    `json:"data,omitempty"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Error      any         `json:"errors,omitempty"`
+	Data       any         `json:"data,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
 }
 
 func ResponseBuilder(c *gin.Context, code int, what string, err, data any) {
+	c.JSON(code, newResponse(code, what, err, data))
+}
+
+func PaginatedResponseBuilder(c *gin.Context, code int, what string, data any, pagination *Pagination) {
+	response := newResponse(code, what, nil, data)
+	response.Pagination = pagination
+
+	c.JSON(code, response)
+}
+
+func newResponse(code int, what string, err, data any) Response {
 	response := Response{
 		Data:    data,
 		Error:   err,
@@ -23,5 +35,5 @@ func ResponseBuilder(c *gin.Context, code int, what string, err, data any) {
 		response.Message += " failed"
 	}
 
-	c.JSON(code, response)
+	return response
 }
@@ -2,16 +2,55 @@ package helpers
 
 import (
 	"fmt"
+	"goproject/internal/domain/model"
 	"regexp"
 	"strings"
 	"time"
 )
 
 func GenerateSlug(s string) string {
+	return fmt.Sprintf("%s-%d", Slugify(s), time.Now().Unix())
+}
+
+func Slugify(s string) string {
 	s = strings.ToLower(s)
 	reg := regexp.MustCompile("[^a-zA-Z0-9]+")
 	s = reg.ReplaceAllString(s, "-")
 	s = strings.Trim(s, "-")
 
-	return fmt.Sprintf("%s-%d", s, time.Now().Unix())
+	return s
+}
+
+// SlugifyTag is Slugify keeping letters and digits of any script, so tags like "日本語" or "café" keep a slug of
+// their own instead of being dropped or merged. Tag slugs have to be escaped when they're put in a URL.
+func SlugifyTag(s string) string {
+	s = strings.ToLower(s)
+	reg := regexp.MustCompile(`[^\p{L}\p{M}\p{N}]+`)
+	s = reg.ReplaceAllString(s, "-")
+	s = strings.Trim(s, "-")
+
+	return s
+}
+
+// NormalizeTags turns raw tag names into tags with a slug, dropping empty and duplicate tags.
+// When two names share a slug, the first one is kept as the tag's name.
+func NormalizeTags(names []string) []model.Tag {
+	tags := make([]model.Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := SlugifyTag(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+
+		seen[slug] = true
+		tags = append(tags, model.Tag{
+			Name: name,
+			Slug: slug,
+		})
+	}
+
+	return tags
 }
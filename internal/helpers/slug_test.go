@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"goproject/internal/domain/model"
+	"testing"
+)
+
+func TestSlugifyTag(t *testing.T) {
+	tests := map[string]string{
+		"Go":             "go",
+		"  Web Dev  ":    "web-dev",
+		"C++ / C#":       "c-c",
+		"café":           "café",
+		"日本語":            "日本語",
+		"Go 1.21":        "go-1-21",
+		"--hello--world": "hello-world",
+		"!!!":            "",
+	}
+
+	for s, want := range tests {
+		if got := SlugifyTag(s); got != want {
+			t.Errorf("SlugifyTag(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []model.Tag
+	}{
+		{
+			name:  "nil",
+			names: nil,
+			want:  []model.Tag{},
+		},
+		{
+			name:  "trims names",
+			names: []string{"  Go  ", "Web Dev"},
+			want:  []model.Tag{{Name: "Go", Slug: "go"}, {Name: "Web Dev", Slug: "web-dev"}},
+		},
+		{
+			name:  "drops empty tags",
+			names: []string{"", "   ", "???", "go"},
+			want:  []model.Tag{{Name: "go", Slug: "go"}},
+		},
+		{
+			name:  "keeps the first name of a slug",
+			names: []string{"Web Dev", "web-dev", "WEB DEV"},
+			want:  []model.Tag{{Name: "Web Dev", Slug: "web-dev"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeTags(tt.names)
+			if len(got) != len(tt.want) {
+				t.Fatalf("NormalizeTags(%q) = %v, want %v", tt.names, got, tt.want)
+			}
+			for i := range got {
+				if got[i].Name != tt.want[i].Name || got[i].Slug != tt.want[i].Slug {
+					t.Errorf("NormalizeTags(%q)[%d] = %v, want %v", tt.names, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// APIBasePath is where every API route is mounted.
+const APIBasePath = "/api/v1"
+
+// appURL is set by LoadAppURL at startup.
+var appURL string
+
+// LoadAppURL reads the public base URL of the app from APP_URL, it has to be called before serving requests. It's
+// required rather than taken from the request, the Host header is up to the client and links sent by email must
+// never point anywhere else than the app.
+func LoadAppURL() error {
+	value := os.Getenv("APP_URL")
+	if value == "" {
+		return errors.New("APP_URL must be set to the public URL of the app, e.g. https://blog.example.com")
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid APP_URL %s, must be an http or https URL", value)
+	}
+
+	appURL = strings.TrimSuffix(value, "/")
+	return nil
+}
+
+// AppURL is the public base URL of the app, from APP_URL.
+func AppURL() string {
+	return appURL
+}
+
+// APIURL is the absolute URL of the given API path, e.g. APIURL("/auth/verify").
+func APIURL(path string) string {
+	return AppURL() + APIBasePath + path
+}
@@ -38,6 +38,10 @@ func errorMessage(tag, param string) string {
 		return fmt.Sprintf("This field has to be at least %s characters long", param)
 	case "max":
 		return fmt.Sprintf("This field length can't exceed %s characters", param)
+	case "oneof":
+		return fmt.Sprintf("This field has to be one of: %s", strings.ReplaceAll(param, " ", ", "))
+	case "required_if":
+		return "This field is required"
 	case "password":
 		return "This field must contains at least 1 numerical character, 1 symbol and 1 uppercase letter"
 	default:
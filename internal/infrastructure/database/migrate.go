@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the key of the advisory lock held while a migration runs,
+// so two instances starting at the same time can't apply the same migration twice.
+const migrationLockID = 727465
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null;type:varchar(255)"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator reads the migrations embedded in the binary, every version needs both an up and a down file.
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := migrationFiles.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has more than one name", version)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Status lists every known migration, AppliedAt is nil for the ones that haven't been applied yet.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Unknown lists the versions applied to the database that aren't embedded in this binary, which means the schema is
+// newer than the app.
+func (m *Migrator) Unknown(ctx context.Context) ([]uint, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []uint
+	for version := range applied {
+		if !m.exists(version) {
+			unknown = append(unknown, version)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		return unknown[i] < unknown[j]
+	})
+
+	return unknown, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rolls back the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+		if _, ok := applied[m.migrations[i].Version]; !ok {
+			continue
+		}
+
+		err = m.run(ctx, m.migrations[i], false)
+		if err != nil {
+			return err
+		}
+		steps--
+	}
+
+	return nil
+}
+
+// To migrates the schema up or down to the given version, 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	if version != 0 && !m.exists(version) {
+		return fmt.Errorf("migration %d doesn't exist", version)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; ok && migration.Version > version {
+			err = m.run(ctx, migration, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+			err = m.run(ctx, migration, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// run applies or rolls back a single migration in its own transaction.
+// The migration is skipped if another instance already did the same while this one was waiting for the lock.
+func (m *Migrator) run(ctx context.Context, migration Migration, up bool) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if (count > 0) == up {
+			return nil
+		}
+
+		script := migration.Down
+		if up {
+			script = migration.Up
+		}
+
+		err = tx.Exec(script).Error
+		if err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		if !up {
+			return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+		}
+		return tx.Create(&schemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[uint]time.Time, error) {
+	err := m.db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []schemaMigration
+	err = m.db.WithContext(ctx).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+func (m *Migrator) exists(version uint) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS list_posts;
+DROP TABLE IF EXISTS lists;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS posts;
+DROP TABLE IF EXISTS blogs;
+DROP TABLE IF EXISTS users;
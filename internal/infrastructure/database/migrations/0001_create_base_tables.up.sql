@@ -0,0 +1,60 @@
+-- tables that existed before migrations were introduced, IF NOT EXISTS lets databases created by AutoMigrate adopt them
+
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial PRIMARY KEY,
+    email varchar(255) NOT NULL CONSTRAINT users_email_key UNIQUE,
+    username varchar(255) NOT NULL,
+    name varchar(255) NOT NULL,
+    password bytea NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+
+CREATE TABLE IF NOT EXISTS blogs (
+    id bigserial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    description text,
+    owner varchar(255) NOT NULL CONSTRAINT fk_blogs_user REFERENCES users (username),
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blogs_owner ON blogs (owner);
+
+CREATE TABLE IF NOT EXISTS posts (
+    id bigserial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    slug varchar(510) NOT NULL,
+    content text NOT NULL,
+    blog_id bigint NOT NULL CONSTRAINT fk_blogs_posts REFERENCES blogs (id),
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_posts_slug ON posts (slug);
+CREATE INDEX IF NOT EXISTS idx_posts_blog_id ON posts (blog_id);
+
+CREATE TABLE IF NOT EXISTS comments (
+    id bigserial PRIMARY KEY,
+    commenter varchar(255) NOT NULL CONSTRAINT fk_comments_user REFERENCES users (username),
+    post_id bigint NOT NULL CONSTRAINT fk_comments_post REFERENCES posts (id) ON DELETE CASCADE,
+    content text,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS lists (
+    id bigserial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    slug varchar(510) NOT NULL,
+    description text,
+    owner varchar(255) NOT NULL CONSTRAINT fk_lists_user REFERENCES users (username),
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_lists_slug ON lists (slug);
+
+CREATE TABLE IF NOT EXISTS list_posts (
+    list_id bigint CONSTRAINT fk_list_posts_list REFERENCES lists (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    post_id bigint CONSTRAINT fk_list_posts_post REFERENCES posts (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_list_id_post_id ON list_posts (list_id, post_id);
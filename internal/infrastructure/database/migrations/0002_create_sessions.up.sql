@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_sessions_user REFERENCES users (username),
+    revoked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_username ON sessions (username);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id bigserial PRIMARY KEY,
+    session_id bigint NOT NULL CONSTRAINT fk_sessions_refresh_tokens REFERENCES sessions (id) ON DELETE CASCADE,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
@@ -0,0 +1,5 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'published';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS published_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_posts_status ON posts (status);
+
+UPDATE posts SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
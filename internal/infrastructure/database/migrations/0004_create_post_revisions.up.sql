@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id bigserial PRIMARY KEY,
+    post_id bigint NOT NULL CONSTRAINT fk_posts_revisions REFERENCES posts (id) ON DELETE CASCADE,
+    revision bigint NOT NULL,
+    title varchar(255) NOT NULL,
+    content text NOT NULL,
+    restored_from bigint,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_id_revision ON post_revisions (post_id, revision);
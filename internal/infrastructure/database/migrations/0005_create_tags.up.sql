@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id bigserial PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    slug varchar(50) NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_slug ON tags (slug);
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id bigint CONSTRAINT fk_post_tags_post REFERENCES posts (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    tag_id bigint CONSTRAINT fk_post_tags_tag REFERENCES tags (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_id_tag_id ON post_tags (post_id, tag_id);
+CREATE INDEX IF NOT EXISTS idx_post_tags_tag_id ON post_tags (tag_id);
@@ -0,0 +1,3 @@
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS parent_id bigint CONSTRAINT fk_comments_parent REFERENCES comments (id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments (parent_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS follows (
+    id bigserial PRIMARY KEY,
+    follower varchar(255) NOT NULL CONSTRAINT fk_follows_follower_user REFERENCES users (username) ON DELETE CASCADE,
+    followee varchar(255) NOT NULL CONSTRAINT fk_follows_followee_user REFERENCES users (username) ON DELETE CASCADE,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follower_followee ON follows (follower, followee);
+CREATE INDEX IF NOT EXISTS idx_follows_followee ON follows (followee);
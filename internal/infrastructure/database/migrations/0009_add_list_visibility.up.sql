@@ -0,0 +1 @@
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS is_public boolean NOT NULL DEFAULT false;
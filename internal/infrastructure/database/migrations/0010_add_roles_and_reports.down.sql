@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reports;
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role varchar(20) NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at timestamptz;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS hidden_at timestamptz;
+CREATE TABLE IF NOT EXISTS reports (
+    id bigserial PRIMARY KEY,
+    reporter varchar(255) NOT NULL CONSTRAINT fk_reports_user REFERENCES users (username) ON DELETE CASCADE,
+    post_id bigint NOT NULL CONSTRAINT fk_reports_post REFERENCES posts (id) ON DELETE CASCADE,
+    comment_id bigint CONSTRAINT fk_reports_comment REFERENCES comments (id) ON DELETE CASCADE,
+    reason text NOT NULL,
+    resolved_by varchar(255) CONSTRAINT fk_reports_resolver REFERENCES users (username) ON DELETE SET NULL,
+    resolved_at timestamptz,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_reports_post_id ON reports (post_id);
+CREATE INDEX IF NOT EXISTS idx_reports_comment_id ON reports (comment_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_user_tokens_user REFERENCES users (username) ON DELETE CASCADE,
+    purpose varchar(30) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tokens_token_hash ON user_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_username ON user_tokens (username);
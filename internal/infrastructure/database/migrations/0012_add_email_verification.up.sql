@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at timestamptz;
+-- accounts that existed before verification was introduced are trusted as they are
+UPDATE users SET email_verified_at = COALESCE(created_at, now()) WHERE email_verified_at IS NULL;
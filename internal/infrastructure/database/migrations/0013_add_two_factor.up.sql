@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret varchar(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled_at timestamptz;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step bigint NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_recovery_codes_user REFERENCES users (username) ON DELETE CASCADE,
+    code_hash varchar(64) NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_username_code_hash ON recovery_codes (username, code_hash);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_personal_access_tokens_user REFERENCES users (username) ON DELETE CASCADE,
+    name varchar(100) NOT NULL,
+    prefix varchar(12) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    scopes text NOT NULL,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_personal_access_tokens_token_hash ON personal_access_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_username ON personal_access_tokens (username);
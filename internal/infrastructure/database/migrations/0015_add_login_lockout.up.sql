@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key varchar(300) PRIMARY KEY,
+    failures integer NOT NULL,
+    last_failed_at timestamptz NOT NULL,
+    locked_until timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_last_failed_at ON login_attempts (last_failed_at);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id bigserial PRIMARY KEY,
+    action varchar(50) NOT NULL,
+    username varchar(255) NOT NULL,
+    actor varchar(255) NOT NULL,
+    ip varchar(45) NOT NULL,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_username ON audit_logs (username);
@@ -0,0 +1,4 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS render_version;
+ALTER TABLE posts DROP COLUMN IF EXISTS reading_time_minutes;
+ALTER TABLE posts DROP COLUMN IF EXISTS excerpt;
+ALTER TABLE posts DROP COLUMN IF EXISTS content_html;
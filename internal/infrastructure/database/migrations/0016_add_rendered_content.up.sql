@@ -0,0 +1,5 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS content_html text NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS excerpt varchar(300) NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS reading_time_minutes integer NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS render_version integer NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_posts_render_version ON posts (render_version);
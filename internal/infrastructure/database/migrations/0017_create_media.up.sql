@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS media (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_media_user REFERENCES users (username) ON DELETE CASCADE,
+    key varchar(300) NOT NULL,
+    filename varchar(255) NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_key ON media (key);
+CREATE INDEX IF NOT EXISTS idx_media_username ON media (username);
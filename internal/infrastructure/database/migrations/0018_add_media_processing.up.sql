@@ -0,0 +1,20 @@
+-- uploads made before processing existed are processed as well
+ALTER TABLE media ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'pending';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS attempts integer NOT NULL DEFAULT 0;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS processing_error varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS width integer NOT NULL DEFAULT 0;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS height integer NOT NULL DEFAULT 0;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_media_status ON media (status);
+
+CREATE TABLE IF NOT EXISTS media_variants (
+    id bigserial PRIMARY KEY,
+    media_id bigint NOT NULL CONSTRAINT fk_media_variants_media REFERENCES media (id) ON DELETE CASCADE,
+    key varchar(300) NOT NULL,
+    width integer NOT NULL,
+    height integer NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size bigint NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_variants_key ON media_variants (key);
+CREATE INDEX IF NOT EXISTS idx_media_variants_media_id ON media_variants (media_id);
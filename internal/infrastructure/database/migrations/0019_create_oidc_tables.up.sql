@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS user_identities (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_user_identities_user REFERENCES users (username) ON DELETE CASCADE,
+    issuer varchar(255) NOT NULL,
+    subject varchar(255) NOT NULL,
+    email varchar(255) NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_issuer_subject ON user_identities (issuer, subject);
+CREATE INDEX IF NOT EXISTS idx_user_identities_username ON user_identities (username);
+
+CREATE TABLE IF NOT EXISTS oidc_auth_requests (
+    id bigserial PRIMARY KEY,
+    state_hash varchar(64) NOT NULL,
+    code_verifier varchar(128) NOT NULL,
+    nonce varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oidc_auth_requests_state_hash ON oidc_auth_requests (state_hash);
+CREATE INDEX IF NOT EXISTS idx_oidc_auth_requests_expires_at ON oidc_auth_requests (expires_at);
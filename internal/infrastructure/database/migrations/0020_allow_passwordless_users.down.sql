@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_user_tokens_username_purpose_created_at;
+
+-- passwordless accounts get an empty password, which never matches, they can still set one through forgot password
+UPDATE users SET password = ''::bytea WHERE password IS NULL;
+ALTER TABLE users ALTER COLUMN password SET NOT NULL;
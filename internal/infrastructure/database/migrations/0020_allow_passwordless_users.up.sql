@@ -0,0 +1,4 @@
+-- accounts created through a magic link or single sign-on don't have a password until the user sets one
+ALTER TABLE users ALTER COLUMN password DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_username_purpose_created_at ON user_tokens (username, purpose, created_at);
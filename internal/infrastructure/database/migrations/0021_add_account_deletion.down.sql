@@ -0,0 +1,8 @@
+-- anonymized comments have nobody left to belong to
+DELETE FROM comments WHERE commenter IS NULL;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comments_user;
+ALTER TABLE comments ADD CONSTRAINT fk_comments_user FOREIGN KEY (commenter) REFERENCES users (username);
+ALTER TABLE comments ALTER COLUMN commenter SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users (deletion_scheduled_at);
+
+-- comments outlive their commenter's account, they're anonymized instead
+ALTER TABLE comments ALTER COLUMN commenter DROP NOT NULL;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comments_user;
+ALTER TABLE comments ADD CONSTRAINT fk_comments_user FOREIGN KEY (commenter) REFERENCES users (username) ON DELETE SET NULL;
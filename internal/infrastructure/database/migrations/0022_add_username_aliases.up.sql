@@ -0,0 +1,36 @@
+-- renaming a user renames every reference to them along with it
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS fk_blogs_user;
+ALTER TABLE blogs ADD CONSTRAINT fk_blogs_user FOREIGN KEY (owner) REFERENCES users (username) ON UPDATE CASCADE;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_comments_user;
+ALTER TABLE comments ADD CONSTRAINT fk_comments_user FOREIGN KEY (commenter) REFERENCES users (username) ON UPDATE CASCADE ON DELETE SET NULL;
+ALTER TABLE lists DROP CONSTRAINT IF EXISTS fk_lists_user;
+ALTER TABLE lists ADD CONSTRAINT fk_lists_user FOREIGN KEY (owner) REFERENCES users (username) ON UPDATE CASCADE;
+ALTER TABLE sessions DROP CONSTRAINT IF EXISTS fk_sessions_user;
+ALTER TABLE sessions ADD CONSTRAINT fk_sessions_user FOREIGN KEY (username) REFERENCES users (username) ON UPDATE CASCADE;
+ALTER TABLE follows DROP CONSTRAINT IF EXISTS fk_follows_follower_user;
+ALTER TABLE follows ADD CONSTRAINT fk_follows_follower_user FOREIGN KEY (follower) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE follows DROP CONSTRAINT IF EXISTS fk_follows_followee_user;
+ALTER TABLE follows ADD CONSTRAINT fk_follows_followee_user FOREIGN KEY (followee) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS fk_reports_user;
+ALTER TABLE reports ADD CONSTRAINT fk_reports_user FOREIGN KEY (reporter) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS fk_reports_resolver;
+ALTER TABLE reports ADD CONSTRAINT fk_reports_resolver FOREIGN KEY (resolved_by) REFERENCES users (username) ON UPDATE CASCADE ON DELETE SET NULL;
+ALTER TABLE user_tokens DROP CONSTRAINT IF EXISTS fk_user_tokens_user;
+ALTER TABLE user_tokens ADD CONSTRAINT fk_user_tokens_user FOREIGN KEY (username) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE recovery_codes DROP CONSTRAINT IF EXISTS fk_recovery_codes_user;
+ALTER TABLE recovery_codes ADD CONSTRAINT fk_recovery_codes_user FOREIGN KEY (username) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE personal_access_tokens DROP CONSTRAINT IF EXISTS fk_personal_access_tokens_user;
+ALTER TABLE personal_access_tokens ADD CONSTRAINT fk_personal_access_tokens_user FOREIGN KEY (username) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE media DROP CONSTRAINT IF EXISTS fk_media_user;
+ALTER TABLE media ADD CONSTRAINT fk_media_user FOREIGN KEY (username) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+ALTER TABLE user_identities DROP CONSTRAINT IF EXISTS fk_user_identities_user;
+ALTER TABLE user_identities ADD CONSTRAINT fk_user_identities_user FOREIGN KEY (username) REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS username_aliases (
+    id bigserial PRIMARY KEY,
+    old_username varchar(255) NOT NULL,
+    username varchar(255) NOT NULL CONSTRAINT fk_username_aliases_user REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_username_aliases_old_username ON username_aliases (old_username);
+CREATE INDEX IF NOT EXISTS idx_username_aliases_username ON username_aliases (username);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS social_links;
+
+ALTER TABLE users DROP COLUMN IF EXISTS show_activity;
+ALTER TABLE users DROP COLUMN IF EXISTS show_follows;
+ALTER TABLE users DROP COLUMN IF EXISTS show_location;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_media_id;
+ALTER TABLE users DROP COLUMN IF EXISTS location;
+ALTER TABLE users DROP COLUMN IF EXISTS website;
+ALTER TABLE users DROP COLUMN IF EXISTS bio;
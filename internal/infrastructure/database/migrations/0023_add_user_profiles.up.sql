@@ -0,0 +1,18 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio text NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS website varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS location varchar(100) NOT NULL DEFAULT '';
+-- the avatar is one of the user's uploads, it's gone along with the upload
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_media_id bigint CONSTRAINT fk_users_avatar_media REFERENCES media (id) ON DELETE SET NULL;
+-- privacy settings, everything shows on the public profile until the user hides it
+ALTER TABLE users ADD COLUMN IF NOT EXISTS show_location boolean NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS show_follows boolean NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS show_activity boolean NOT NULL DEFAULT true;
+
+CREATE TABLE IF NOT EXISTS social_links (
+    id bigserial PRIMARY KEY,
+    username varchar(255) NOT NULL CONSTRAINT fk_social_links_user REFERENCES users (username) ON UPDATE CASCADE ON DELETE CASCADE,
+    label varchar(50) NOT NULL,
+    url varchar(255) NOT NULL,
+    position integer NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_social_links_username ON social_links (username);
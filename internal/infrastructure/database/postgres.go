@@ -2,7 +2,6 @@ package database
 
 import (
 	"fmt"
-	"goproject/internal/domain/model"
 	"os"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -43,18 +42,6 @@ func NewPostgresDB() (*Database, error) {
 		return nil, err
 	}
 
-	err = db.AutoMigrate(
-		&model.List{},
-		&model.User{},
-		&model.Blog{},
-		&model.Post{},
-		&model.Comment{},
-		&model.ListPost{},
-	)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Database{
 		DB: db,
 	}, nil
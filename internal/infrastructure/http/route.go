@@ -1,15 +1,35 @@
 package httproute
 
 import (
+	"goproject/internal/app/delivery/http/accesstoken"
+	"goproject/internal/app/delivery/http/account"
+	"goproject/internal/app/delivery/http/admin"
 	"goproject/internal/app/delivery/http/auth"
 	"goproject/internal/app/delivery/http/blog"
 	"goproject/internal/app/delivery/http/comment"
+	"goproject/internal/app/delivery/http/feed"
+	"goproject/internal/app/delivery/http/follow"
 	"goproject/internal/app/delivery/http/list"
+	"goproject/internal/app/delivery/http/media"
 	"goproject/internal/app/delivery/http/post"
+	"goproject/internal/app/delivery/http/report"
+	"goproject/internal/app/delivery/http/search"
+	"goproject/internal/app/delivery/http/tag"
+	"goproject/internal/app/delivery/http/twofactor"
 	"goproject/internal/app/delivery/http/user"
+	"goproject/internal/app/delivery/http/verification"
+	"goproject/internal/app/delivery/http/wellknown"
+	accountusecase "goproject/internal/app/usecase/account"
+	lockoutusecase "goproject/internal/app/usecase/lockout"
+	mediausecase "goproject/internal/app/usecase/media"
 	"goproject/internal/helpers"
+	"goproject/internal/infrastructure/mail"
+	"goproject/internal/infrastructure/storage"
 
 	"log/slog"
+	"os"
+	"strings"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -19,9 +39,17 @@ import (
 	"gorm.io/gorm"
 )
 
-func NewRoute(db *gorm.DB, logger *slog.Logger) *gin.Engine {
+// NewRoute sets up every route. What holds state or runs in the background is built once by the caller and shared with
+// the scheduled jobs: the blob store, the mailer, the lockout and the media and account usecases.
+func NewRoute(db *gorm.DB, store storage.BlobStore, mailer mail.Mailer, lockout lockoutusecase.LockoutUsecase, mediaUsecase mediausecase.MediaUsecase, mediaLimits mediausecase.Limits, accountUsecase accountusecase.AccountUsecase, logger *slog.Logger) *gin.Engine {
 	r := gin.Default()
 
+	// the client IP is what login lockouts go by, so X-Forwarded-For is only believed when a trusted proxy sent it
+	err := r.SetTrustedProxies(trustedProxies())
+	if err != nil {
+		panic(err)
+	}
+
 	r.GET("/", func(c *gin.Context) {
 		c.Data(200, "text/html", []byte(`<a href="/swagger/index.html">Swagger Docs</a>`))
 	})
@@ -29,16 +57,36 @@ func NewRoute(db *gorm.DB, logger *slog.Logger) *gin.Engine {
 		v.RegisterValidation("password", helpers.ValidatePassword)
 	}
 
-	api := r.Group("/api/v1")
+	wellknown.Route(&r.RouterGroup, db, logger)
+
+	api := r.Group(helpers.APIBasePath)
 
-	auth.Route(api, db, logger)
-	user.Route(api, db, logger)
+	auth.Route(api, db, mailer, lockout, logger)
+	verification.Route(api, db, mailer, logger)
+	twofactor.Route(api, db, logger)
+	user.Route(api, db, store, mailer, logger)
+	account.Route(api, db, accountUsecase)
+	accesstoken.Route(api, db, logger)
 	blog.Route(api, db, logger)
 	post.Route(api, db, logger)
 	comment.Route(api, db, logger)
 	list.Route(api, db, logger)
+	media.Route(api, db, mediaUsecase, mediaLimits)
+	tag.Route(api, db, logger)
+	search.Route(api, db, logger)
+	follow.Route(api, db, logger)
+	feed.Route(api, db, logger)
+	report.Route(api, db, logger)
+	admin.Route(api, db, lockout, logger)
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	return r
 }
+
+// trustedProxies reads TRUSTED_PROXIES, a comma or space separated list of IPs and CIDRs. None are trusted without it.
+func trustedProxies() []string {
+	return strings.FieldsFunc(os.Getenv("TRUSTED_PROXIES"), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
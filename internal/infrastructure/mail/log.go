@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogMailer only logs that a message would have been sent, it's meant for development. The body is left out, it holds
+// tokens and links that would let anyone reading the logs into the account. Use the file driver, or SMTP with
+// Mailpit, to read the emails.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{
+		logger: logger,
+	}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.InfoContext(ctx, "mail not sent, MAIL_DRIVER is log", "to", msg.To, "subject", msg.Subject, "body_bytes", len(msg.Body))
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package mail sends the emails the app needs, e.g. password reset links, through a configurable transport.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"os"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewMailer picks the transport from MAIL_DRIVER: smtp, file, or log when it isn't set.
+func NewMailer(logger *slog.Logger) (Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Go-Blog <no-reply@localhost>"
+	}
+
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "smtp":
+		return NewSMTPMailer(os.Getenv("SMTP_HOST"), os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from)
+	case "file":
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = "mails"
+		}
+		return NewFileMailer(dir, from)
+	case "", "log":
+		return NewLogMailer(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %s, must be smtp, file or log", driver)
+	}
+}
+
+// build renders the message as a plain text RFC 5322 email.
+func (msg Message) build(from string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	return b.Bytes()
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+)
+
+type SMTPMailer struct {
+	addr     string
+	auth     smtp.Auth
+	from     string
+	envelope string
+}
+
+// NewSMTPMailer sends through an SMTP server, authenticating only when a username is given
+// so local sinks like Mailpit work out of the box.
+func NewSMTPMailer(host, port, username, password, from string) (*SMTPMailer, error) {
+	if host == "" || port == "" {
+		return nil, errors.New("SMTP_HOST and SMTP_PORT are required by the smtp mail driver")
+	}
+
+	sender, err := netmail.ParseAddress(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, port),
+		auth:     auth,
+		from:     from,
+		envelope: sender.Address,
+	}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	return smtp.SendMail(m.addr, m.auth, m.envelope, []string{msg.To}, msg.build(m.from))
+}
@@ -0,0 +1,406 @@
+// Package oidc logs users in through an OpenID Connect provider, using the authorization code flow with PKCE.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidIDToken means the provider's answer can't be trusted, e.g. a bad signature or a nonce mismatch.
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // empty for public clients, which only rely on PKCE
+	Scopes       []string
+	// AutoProvision creates an account for users logging in for the first time, rather than turning them away
+	AutoProvision bool
+}
+
+// Claims are what's taken from a validated ID token.
+type Claims struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+type Provider interface {
+	// AuthCodeURL is where the user is sent to log in, redirectURL is where the provider sends them back with a code.
+	AuthCodeURL(ctx context.Context, redirectURL, state, nonce, codeVerifier string) (string, error)
+	// Exchange trades the code for an ID token and validates it against the nonce.
+	Exchange(ctx context.Context, redirectURL, code, codeVerifier, nonce string) (*Claims, error)
+	AutoProvision() bool
+}
+
+// NewProvider configures the provider from OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET, OIDC_SCOPES and
+// OIDC_AUTO_PROVISION. It returns nil when OIDC_ISSUER isn't set, i.e. single sign-on is turned off.
+func NewProvider() (Provider, error) {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil, nil
+	}
+
+	clientID := os.Getenv("OIDC_CLIENT_ID")
+	if clientID == "" {
+		return nil, errors.New("OIDC_CLIENT_ID must be set along with OIDC_ISSUER")
+	}
+
+	scopes := strings.Fields(os.Getenv("OIDC_SCOPES"))
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	client, err := NewClient(Config{
+		Issuer:        issuer,
+		ClientID:      clientID,
+		ClientSecret:  os.Getenv("OIDC_CLIENT_SECRET"),
+		Scopes:        scopes,
+		AutoProvision: os.Getenv("OIDC_AUTO_PROVISION") != "false",
+	}, &http.Client{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// how long the discovery document is trusted before it's loaded again
+const discoveryTTL = time.Hour
+
+// keys are loaded again when a token is signed with an unknown one, but not more often than this
+const minJWKSRefresh = time.Minute
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Client is a relying party of one provider. The discovery document and the signing keys are loaded when they're
+// first needed, so the app starts even while the provider is down. mu only guards the cached values, it's never held
+// while talking to the provider.
+type Client struct {
+	cfg    Config
+	client *http.Client
+
+	mu           sync.Mutex
+	discovery    *discovery
+	discoveredAt time.Time
+	keys         map[string]any
+	keysLoadedAt time.Time
+	// keysRefresh is closed once the keys being fetched are in, it's nil when no one is fetching them
+	keysRefresh chan struct{}
+}
+
+func NewClient(cfg Config, client *http.Client) (*Client, error) {
+	issuer, err := url.Parse(cfg.Issuer)
+	if err != nil || (issuer.Scheme != "https" && issuer.Scheme != "http") || issuer.Host == "" {
+		return nil, fmt.Errorf("invalid OIDC issuer %s, must be an http or https URL", cfg.Issuer)
+	}
+
+	return &Client{
+		cfg:    cfg,
+		client: client,
+	}, nil
+}
+
+// CodeChallenge derives the S256 PKCE challenge sent along with the authorization request from the verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *Client) AutoProvision() bool {
+	return p.cfg.AutoProvision
+}
+
+func (p *Client) AuthCodeURL(ctx context.Context, redirectURL, state, nonce, codeVerifier string) (string, error) {
+	d, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", redirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", CodeChallenge(codeVerifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (p *Client) Exchange(ctx context.Context, redirectURL, code, codeVerifier, nonce string) (*Claims, error) {
+	d, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if p.cfg.ClientSecret == "" {
+		form.Set("client_id", p.cfg.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err = json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("oidc token endpoint: %s: %w", res.Status, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc token endpoint: %s: %s %s", res.Status, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return nil, fmt.Errorf("%w: the token response has no id_token", ErrInvalidIDToken)
+	}
+
+	return p.verify(ctx, d, body.IDToken, nonce)
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp"`
+	Email             string `json:"email"`
+	EmailVerified     any    `json:"email_verified"` // some providers send it as a string
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// verify checks the ID token as https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation says.
+func (p *Client) verify(ctx context.Context, d *discovery, raw, nonce string) (*Claims, error) {
+	claims := new(idTokenClaims)
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.key(ctx, d, kid)
+		if err != nil {
+			return nil, err
+		}
+		if !algMatchesKey(token.Method, key) {
+			return nil, fmt.Errorf("alg %s doesn't match the key", token.Method.Alg())
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIDToken, err)
+	}
+
+	// exp is only checked by the parser when it's there, but an ID token without one would be valid forever
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: no expiry", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: issued to %s", ErrInvalidIDToken, claims.AuthorizedParty)
+	}
+
+	return &Claims{
+		Issuer:            claims.Issuer,
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified == true || claims.EmailVerified == "true",
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// loadDiscovery fetches the document without holding the lock, so a slow provider doesn't hold up logins that can be
+// served from the cache. Two logins may both fetch it when it expires, which is harmless.
+func (p *Client) loadDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	cached, discoveredAt := p.discovery, p.discoveredAt
+	p.mu.Unlock()
+
+	if cached != nil && time.Since(discoveredAt) < discoveryTTL {
+		return cached, nil
+	}
+
+	d := new(discovery)
+	err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.Issuer, "/")+"/.well-known/openid-configuration", d)
+	if err != nil {
+		return nil, err
+	}
+
+	// a document claiming another issuer could be used to pass off its tokens as ours
+	if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %s doesn't match OIDC_ISSUER %s", d.Issuer, p.cfg.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: authorization_endpoint, token_endpoint and jwks_uri are required")
+	}
+
+	p.mu.Lock()
+	p.discovery = d
+	p.discoveredAt = time.Now()
+	p.mu.Unlock()
+	return d, nil
+}
+
+// key finds the key a token is signed with. The keys are fetched without holding the lock, and only by one login at
+// a time, the others wait for its result rather than all hitting the provider at once.
+func (p *Client) key(ctx context.Context, d *discovery, kid string) (any, error) {
+	p.mu.Lock()
+	if key, ok := p.lookupKey(kid); ok {
+		p.mu.Unlock()
+		return key, nil
+	}
+
+	if refresh := p.keysRefresh; refresh != nil {
+		p.mu.Unlock()
+		select {
+		case <-refresh:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if key, ok := p.lookupKey(kid); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	// the provider may have rotated its keys
+	if time.Since(p.keysLoadedAt) < minJWKSRefresh {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	refresh := make(chan struct{})
+	p.keysRefresh = refresh
+	p.mu.Unlock()
+
+	keys, err := p.fetchKeys(ctx, d.JWKSURI)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keysRefresh = nil
+	close(refresh)
+
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.keysLoadedAt = time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *Client) fetchKeys(ctx context.Context, jwksURI string) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err := p.getJSON(ctx, jwksURI, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			// one key we can't use shouldn't make the others unusable
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+// lookupKey also accepts tokens without a kid when the provider has a single key.
+func (p *Client) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *Client) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, res.Status)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(v)
+}
@@ -0,0 +1,291 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"goproject/internal/infrastructure/oidc/oidctest"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID    = "blog"
+	testRedirectURL = "http://app.test/auth/oidc/callback"
+)
+
+func newTestClient(t *testing.T) (*Client, *oidctest.Provider) {
+	t.Helper()
+
+	idp, err := oidctest.NewProvider(testClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(idp.Close)
+
+	client, err := NewClient(Config{
+		Issuer:   idp.Issuer,
+		ClientID: testClientID,
+		Scopes:   []string{"openid", "email"},
+	}, idp.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, idp
+}
+
+// login goes through the whole flow and returns what Exchange does with the code the provider hands out.
+func login(t *testing.T, client *Client, idp *oidctest.Provider, claims jwt.MapClaims) (*Claims, error) {
+	t.Helper()
+
+	ctx := context.Background()
+	authURL, err := client.AuthCodeURL(ctx, testRedirectURL, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := idp.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client.Exchange(ctx, testRedirectURL, code, "verifier", "nonce")
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636, appendix B
+	got := CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("CodeChallenge() = %s, want %s", got, want)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	authURL, err := client.AuthCodeURL(context.Background(), testRedirectURL, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             testClientID,
+		"redirect_uri":          testRedirectURL,
+		"scope":                 "openid email",
+		"state":                 "state",
+		"nonce":                 "nonce",
+		"code_challenge":        CodeChallenge("verifier"),
+		"code_challenge_method": "S256",
+	}
+	for name, value := range want {
+		if q.Get(name) != value {
+			t.Errorf("%s = %q, want %q", name, q.Get(name), value)
+		}
+	}
+	if q.Has("code_verifier") {
+		t.Error("the code verifier must not leave the app through the browser")
+	}
+}
+
+func TestExchange(t *testing.T) {
+	client, idp := newTestClient(t)
+
+	claims, err := login(t, client, idp, jwt.MapClaims{
+		"sub":                "1234",
+		"email":              "jane@example.com",
+		"email_verified":     true,
+		"name":               "Jane",
+		"preferred_username": "jane",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Claims{
+		Issuer:            idp.Issuer,
+		Subject:           "1234",
+		Email:             "jane@example.com",
+		EmailVerified:     true,
+		Name:              "Jane",
+		PreferredUsername: "jane",
+	}
+	if *claims != want {
+		t.Errorf("Exchange() = %+v, want %+v", *claims, want)
+	}
+}
+
+func TestExchangeEmailVerifiedAsString(t *testing.T) {
+	client, idp := newTestClient(t)
+
+	claims, err := login(t, client, idp, jwt.MapClaims{"sub": "1234", "email": "jane@example.com", "email_verified": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !claims.EmailVerified {
+		t.Error("EmailVerified = false, want true")
+	}
+}
+
+func TestExchangeWrongCodeVerifier(t *testing.T) {
+	client, idp := newTestClient(t)
+	ctx := context.Background()
+
+	authURL, err := client.AuthCodeURL(ctx, testRedirectURL, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := idp.Authorize(authURL, jwt.MapClaims{"sub": "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// someone who intercepted the code doesn't have the verifier
+	_, err = client.Exchange(ctx, testRedirectURL, code, "another verifier", "nonce")
+	if err == nil {
+		t.Fatal("Exchange() succeeded with the wrong code verifier")
+	}
+}
+
+func TestExchangeInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{"wrong issuer", jwt.MapClaims{"iss": "https://evil.example.com"}},
+		{"wrong audience", jwt.MapClaims{"aud": "another-client"}},
+		{"several audiences without azp", jwt.MapClaims{"aud": []string{testClientID, "another-client"}}},
+		{"several audiences for another party", jwt.MapClaims{"aud": []string{testClientID, "another-client"}, "azp": "another-client"}},
+		{"expired", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}},
+		{"no expiry", jwt.MapClaims{"exp": nil}},
+		{"issued in the future", jwt.MapClaims{"iat": time.Now().Add(time.Hour).Unix()}},
+		{"wrong nonce", jwt.MapClaims{"nonce": "another nonce"}},
+		{"no nonce", jwt.MapClaims{"nonce": nil}},
+		{"no subject", jwt.MapClaims{"sub": nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, idp := newTestClient(t)
+
+			claims := jwt.MapClaims{"sub": "1234"}
+			for name, value := range tt.claims {
+				claims[name] = value
+			}
+
+			_, err := login(t, client, idp, claims)
+			if !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("Exchange() error = %v, want %v", err, ErrInvalidIDToken)
+			}
+		})
+	}
+}
+
+func TestExchangeSeveralAudiences(t *testing.T) {
+	client, idp := newTestClient(t)
+
+	_, err := login(t, client, idp, jwt.MapClaims{"sub": "1234", "aud": []string{testClientID, "another-client"}, "azp": testClientID})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyUnknownKey(t *testing.T) {
+	client, idp := newTestClient(t)
+	ctx := context.Background()
+
+	d, err := client.loadDiscovery(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := idp.Sign(jwt.MapClaims{"iss": idp.Issuer, "aud": testClientID, "sub": "1234", "exp": time.Now().Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// signed by someone else with a key id the provider doesn't have
+	forged, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged.Header["kid"] = "unknown"
+	raw, err := forged.SigningString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.verify(ctx, d, raw+".c2lnbmF0dXJl", "")
+	if !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("verify() error = %v, want %v", err, ErrInvalidIDToken)
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	idp, err := oidctest.NewProvider(testClientID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idp.Close()
+
+	// the document is found, but it claims to be another issuer
+	client, err := NewClient(Config{Issuer: idp.Issuer + "/", ClientID: testClientID}, idp.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.AuthCodeURL(context.Background(), testRedirectURL, "state", "nonce", "verifier")
+	if err == nil {
+		t.Error("AuthCodeURL() succeeded with a discovery document for another issuer")
+	}
+}
+
+func TestKeysFetchedOnce(t *testing.T) {
+	client, idp := newTestClient(t)
+	ctx := context.Background()
+
+	authURL, err := client.AuthCodeURL(ctx, testRedirectURL, "state", "nonce", "verifier")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const logins = 10
+	codes := make([]string, logins)
+	for i := range codes {
+		codes[i], err = idp.Authorize(authURL, jwt.MapClaims{"sub": "1234"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, logins)
+	for _, code := range codes {
+		wg.Add(1)
+		go func(code string) {
+			defer wg.Done()
+			_, err := client.Exchange(ctx, testRedirectURL, code, "verifier", "nonce")
+			errs <- err
+		}(code)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if n := idp.JWKSRequests(); n != 1 {
+		t.Errorf("the keys were fetched %d times, want 1", n)
+	}
+}
@@ -0,0 +1,243 @@
+// Package oidctest runs a fake OpenID Connect provider for tests. It serves the discovery document, the JWKS and the
+// token endpoint, and checks the PKCE verifier the way a real provider does.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "test-key"
+
+// Provider is a fake provider, its issuer is the URL of the server it runs.
+type Provider struct {
+	Issuer   string
+	ClientID string
+
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+
+	jwksRequests atomic.Int64
+}
+
+// grant is what a code stands for, it's handed out by Authorize and traded for an ID token at the token endpoint.
+type grant struct {
+	redirectURI   string
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+// NewProvider starts a provider issuing ID tokens to clientID, it has to be closed once the test is done.
+func NewProvider(clientID string) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		ClientID: clientID,
+		key:      key,
+		grants:   make(map[string]grant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	p.Issuer = p.server.URL
+
+	return p, nil
+}
+
+func (p *Provider) Close() {
+	p.server.Close()
+}
+
+// Client is an HTTP client that can reach the provider.
+func (p *Provider) Client() *http.Client {
+	return p.server.Client()
+}
+
+// JWKSRequests is how many times the keys were fetched.
+func (p *Provider) JWKSRequests() int64 {
+	return p.jwksRequests.Load()
+}
+
+// Authorize does what the provider does once the user logged in at authURL: it checks the request and returns the
+// code the user is sent back with. The ID token gets the claims a provider always sets, which claims can override,
+// a nil value leaves the claim out.
+func (p *Provider) Authorize(authURL string, claims jwt.MapClaims) (string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	if q.Get("response_type") != "code" {
+		return "", fmt.Errorf("response_type is %q, want code", q.Get("response_type"))
+	}
+	if q.Get("client_id") != p.ClientID {
+		return "", fmt.Errorf("client_id is %q, want %q", q.Get("client_id"), p.ClientID)
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		return "", errors.New("the request has no S256 code challenge")
+	}
+	if q.Get("state") == "" {
+		return "", errors.New("the request has no state")
+	}
+
+	now := time.Now()
+	idClaims := jwt.MapClaims{
+		"iss":   p.Issuer,
+		"aud":   p.ClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(5 * time.Minute).Unix(),
+		"nonce": q.Get("nonce"),
+	}
+	for name, value := range claims {
+		if value == nil {
+			delete(idClaims, name)
+			continue
+		}
+		idClaims[name] = value
+	}
+
+	code, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.grants[code] = grant{
+		redirectURI:   q.Get("redirect_uri"),
+		codeChallenge: q.Get("code_challenge"),
+		claims:        idClaims,
+	}
+	p.mu.Unlock()
+
+	return code, nil
+}
+
+// Sign signs claims as the provider, for tokens that don't go through the authorization flow.
+func (p *Provider) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(p.key)
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.Issuer,
+		"authorization_endpoint": p.Issuer + "/authorize",
+		"token_endpoint":         p.Issuer + "/token",
+		"jwks_uri":               p.Issuer + "/jwks",
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	p.jwksRequests.Add(1)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": keyID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", err.Error())
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", r.PostForm.Get("grant_type"))
+		return
+	}
+	if r.PostForm.Get("client_id") != p.ClientID {
+		tokenError(w, "invalid_client", r.PostForm.Get("client_id"))
+		return
+	}
+
+	// a code can only be used once, whether the exchange works or not
+	p.mu.Lock()
+	g, ok := p.grants[r.PostForm.Get("code")]
+	delete(p.grants, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	if !ok {
+		tokenError(w, "invalid_grant", "unknown code")
+		return
+	}
+	if r.PostForm.Get("redirect_uri") != g.redirectURI {
+		tokenError(w, "invalid_grant", "redirect_uri mismatch")
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != g.codeChallenge {
+		tokenError(w, "invalid_grant", "code_verifier doesn't match the code challenge")
+		return
+	}
+
+	idToken, err := p.Sign(g.claims)
+	if err != nil {
+		tokenError(w, "server_error", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Job func(ctx context.Context) error
+
+// Every runs job right away and then once every interval until ctx is done.
+// A failing run is logged and doesn't stop the following ones.
+func Every(ctx context.Context, interval time.Duration, name string, job Job, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := job(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx, err.Error(), "job", name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
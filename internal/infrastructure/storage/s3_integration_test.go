@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"goproject/internal/utils"
+)
+
+// newIntegrationStore connects to the S3 compatible service at S3_TEST_ENDPOINT, e.g. the minio service from
+// docker-compose:
+//
+//	S3_TEST_ENDPOINT=http://localhost:9000 S3_TEST_ACCESS_KEY_ID=minioadmin S3_TEST_SECRET_ACCESS_KEY=minioadmin go test ./internal/infrastructure/storage/
+//
+// The tests are skipped without it.
+func newIntegrationStore(t *testing.T, secretAccessKey string) *S3Store {
+	t.Helper()
+
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT isn't set")
+	}
+	bucket := os.Getenv("S3_TEST_BUCKET")
+	if bucket == "" {
+		bucket = "goblog-test"
+	}
+	if secretAccessKey == "" {
+		secretAccessKey = os.Getenv("S3_TEST_SECRET_ACCESS_KEY")
+	}
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:        endpoint,
+		Region:          os.Getenv("S3_TEST_REGION"),
+		Bucket:          bucket,
+		AccessKeyID:     os.Getenv("S3_TEST_ACCESS_KEY_ID"),
+		SecretAccessKey: secretAccessKey,
+		PathStyle:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// createBucket makes sure the bucket exists, creating one that's already there is fine.
+func createBucket(t *testing.T, store *S3Store) {
+	t.Helper()
+
+	u := *store.endpoint
+	u.Path = "/" + store.cfg.Bucket
+	req, err := http.NewRequest(http.MethodPut, u.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := store.do(req, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusConflict {
+		t.Fatal(s3Error(res))
+	}
+}
+
+func TestS3StoreIntegration(t *testing.T) {
+	store := newIntegrationStore(t, "")
+	createBucket(t, store)
+	ctx := context.Background()
+
+	random, err := utils.GenerateRandomToken(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// characters that need escaping, so the signed path has to match the one sent
+	key := "test/" + random + "/hello world+$=(1).txt"
+	content := []byte("Welcome to the blog.")
+
+	err = store.Put(ctx, key, content, "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Delete(context.Background(), key) })
+
+	r, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("Open() = %q, want %q", got, content)
+	}
+
+	err = store.Delete(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = store.Open(ctx, key)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() after Delete() error = %v, want %v", err, ErrNotFound)
+	}
+
+	// deleting again succeeds, like the local store
+	err = store.Delete(ctx, key)
+	if err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+}
+
+func TestS3StoreIntegrationWrongSecret(t *testing.T) {
+	store := newIntegrationStore(t, "not-the-secret")
+
+	err := store.Put(context.Background(), "test/wrong-secret.txt", []byte("nope"), "text/plain")
+	if err == nil {
+		t.Error("Put() succeeded with the wrong secret")
+	}
+}
@@ -0,0 +1,59 @@
+// Package storage keeps uploaded files, e.g. images used in posts, on a configurable backend.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNotFound is returned when there is no blob with the given key.
+var ErrNotFound = errors.New("blob not found")
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// PublicURL is where the blob can be downloaded from directly, false when it has to be served by the app.
+	PublicURL(key string) (string, bool)
+}
+
+// NewBlobStore picks the backend from STORAGE_DRIVER: s3, or local when it isn't set.
+func NewBlobStore() (BlobStore, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		dir := os.Getenv("STORAGE_DIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		return NewLocalStore(dir)
+	case "s3":
+		return NewS3Store(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       os.Getenv("S3_PATH_STYLE") != "false",
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %s, must be local or s3", driver)
+	}
+}
+
+// validKey keeps keys from escaping the store, e.g. with "../".
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package storage
+
+import "testing"
+
+func TestValidKey(t *testing.T) {
+	tests := map[string]bool{
+		"avatar.png":          true,
+		"media/2024/01/a.png": true,
+		"a..b/c.png":          true,
+		"":                    false,
+		"/etc/passwd":         false,
+		"../secret":           false,
+		"media/../../secret":  false,
+		"media/./a.png":       false,
+		"media//a.png":        false,
+		"media/":              false,
+		"..":                  false,
+	}
+
+	for key, want := range tests {
+		if got := validKey(key); got != want {
+			t.Errorf("validKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
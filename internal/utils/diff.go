@@ -0,0 +1,105 @@
+package utils
+
+import "strings"
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// maxDiffCells bounds the LCS table, i.e. the product of the line counts that differ, so a diff of two huge
+// revisions can't eat up the memory. Past it, the lines that differ are shown as deleted and inserted as a whole.
+const maxDiffCells = 1 << 20
+
+// DiffLines returns a line-level diff turning a into b, based on their longest common subsequence.
+func DiffLines(a, b string) []DiffLine {
+	oldLines := splitLines(a)
+	newLines := splitLines(b)
+
+	// most edits touch a few lines in the middle, the lines around them don't need the table
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix && oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]DiffLine, 0, max(len(oldLines), len(newLines)))
+	for _, line := range oldLines[:prefix] {
+		diff = append(diff, DiffLine{DiffEqual, line})
+	}
+	diff = diffMiddle(diff, oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix])
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		diff = append(diff, DiffLine{DiffEqual, line})
+	}
+
+	return diff
+}
+
+func diffMiddle(diff []DiffLine, oldLines, newLines []string) []DiffLine {
+	n, m := len(oldLines), len(newLines)
+
+	if (n+1)*(m+1) > maxDiffCells {
+		for _, line := range oldLines {
+			diff = append(diff, DiffLine{DiffDelete, line})
+		}
+		for _, line := range newLines {
+			diff = append(diff, DiffLine{DiffInsert, line})
+		}
+		return diff
+	}
+
+	// lcs[i*(m+1)+j] is the length of the LCS of oldLines[i:] and newLines[j:]
+	lcs := make([]int32, (n+1)*(m+1))
+	at := func(i, j int) int32 {
+		return lcs[i*(m+1)+j]
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i*(m+1)+j] = at(i+1, j+1) + 1
+			} else {
+				lcs[i*(m+1)+j] = max(at(i+1, j), at(i, j+1))
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldLines[i] == newLines[j]:
+			diff = append(diff, DiffLine{DiffEqual, oldLines[i]})
+			i++
+			j++
+		case at(i+1, j) >= at(i, j+1):
+			diff = append(diff, DiffLine{DiffDelete, oldLines[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{DiffInsert, newLines[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		diff = append(diff, DiffLine{DiffDelete, oldLines[i]})
+	}
+	for ; j < m; j++ {
+		diff = append(diff, DiffLine{DiffInsert, newLines[j]})
+	}
+
+	return diff
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ImageWidths are the widths images are resized to, so clients can pick one with srcset. Images narrower than the
+// largest one are also kept at their own width, they're never scaled up.
+var ImageWidths = []int{320, 640, 960, 1280, 1920}
+
+const (
+	// decoding takes about 4 bytes per pixel, this keeps a single image under ~160 MB
+	maxImagePixels = 40_000_000
+	jpegQuality    = 82
+)
+
+// ErrInvalidImage means the image itself is the problem, processing it again won't help.
+var ErrInvalidImage = errors.New("invalid image")
+
+type ImageVariant struct {
+	Width       int
+	Height      int
+	ContentType string
+	Ext         string
+	Data        []byte
+}
+
+// ProcessImage decodes a PNG, JPEG, GIF or WebP image and encodes it again at each of ImageWidths it's wider than,
+// sorted by width. Re-encoding drops all metadata, EXIF and GPS included, the EXIF orientation is applied to the
+// pixels first so photos don't end up sideways. Opaque images become JPEGs and the others PNGs, animated GIFs keep
+// their first frame.
+func ProcessImage(data []byte) ([]ImageVariant, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d is too many pixels", ErrInvalidImage, cfg.Width, cfg.Height)
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+	}
+
+	img := toNRGBA(src)
+	if format == "jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	contentType, ext := "image/jpeg", ".jpg"
+	if !img.Opaque() {
+		contentType, ext = "image/png", ".png"
+	}
+
+	widths := variantWidths(img.Bounds().Dx())
+	variants := make([]ImageVariant, len(widths))
+	// from the largest to the smallest, each one is scaled down from the previous, which is a lot faster than
+	// scaling them all down from the original
+	resized := img
+	for i := len(widths) - 1; i >= 0; i-- {
+		resized = resize(resized, widths[i])
+
+		var buf bytes.Buffer
+		if contentType == "image/png" {
+			err = png.Encode(&buf, resized)
+		} else {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegQuality})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		variants[i] = ImageVariant{
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			ContentType: contentType,
+			Ext:         ext,
+			Data:        buf.Bytes(),
+		}
+	}
+
+	return variants, nil
+}
+
+func variantWidths(width int) []int {
+	var widths []int
+	for _, w := range ImageWidths {
+		if w < width {
+			widths = append(widths, w)
+		}
+	}
+	if width <= ImageWidths[len(ImageWidths)-1] {
+		widths = append(widths, width)
+	}
+	return widths
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	img := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Src)
+	return img
+}
+
+func resize(src *image.NRGBA, width int) *image.NRGBA {
+	bounds := src.Bounds()
+	if width == bounds.Dx() {
+		return src
+	}
+
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, xdraw.Src, nil)
+	return dst
+}
+
+// orient turns the image the way the EXIF orientation says it should be shown, see
+// https://www.exif.org/Exif2-2.PDF, page 18.
+func orient(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // mirrored along the top-left to bottom-right diagonal
+				dx, dy = y, x
+			case 6: // has to be rotated 90° clockwise
+				dx, dy = h-1-y, x
+			case 7: // mirrored along the top-right to bottom-left diagonal
+				dx, dy = h-1-y, w-1-x
+			case 8: // has to be rotated 90° counterclockwise
+				dx, dy = y, w-1-x
+			}
+			dst.SetNRGBA(dx, dy, src.NRGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the orientation tag from a JPEG's EXIF segment, 1 (as is) when there's none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		// the image data starts with SOS, EXIF comes before it
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		// orientation is a single SHORT, stored in the first two bytes of the value field
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 1
+}
@@ -10,17 +10,69 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateJWT(claims jwt.MapClaims) (string, error) {
+// jwtKeyring is set by LoadJWTKeyring at startup. Without it, JWTs fall back to HS256 with JWT_SECRET.
+var jwtKeyring *Keyring
+
+// LoadJWTKeyring loads the keyring from JWT_KEYS_DIR when it's set. It has to be called before serving requests.
+func LoadJWTKeyring() error {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		return nil
+	}
+
+	keyring, err := LoadKeyring(dir)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT keys from %s: %w", dir, err)
+	}
+
+	jwtKeyring = keyring
+	return nil
+}
+
+// JWKS returns the public keys JWTs can be verified with, it's empty when JWTs are signed with JWT_SECRET.
+func JWKS() JWKSet {
+	if jwtKeyring == nil {
+		return JWKSet{Keys: []JWK{}}
+	}
+	return jwtKeyring.JWKS(time.Now())
+}
+
+// AccessTokenLifespan is how long a JWT is valid for, JWT_LIFESPAN_MINUTES. JWT_LIFESPAN, in hours, is still read
+// when it isn't set, so existing configurations keep the lifespan they had.
+func AccessTokenLifespan() (time.Duration, error) {
+	if minutes := os.Getenv("JWT_LIFESPAN_MINUTES"); minutes != "" {
+		lifespan, err := strconv.Atoi(minutes)
+		if err != nil || lifespan <= 0 {
+			return 0, errors.New("invalid JWT_LIFESPAN_MINUTES")
+		}
+		return time.Duration(lifespan) * time.Minute, nil
+	}
+
 	lifespan, err := strconv.Atoi(os.Getenv("JWT_LIFESPAN"))
+	if err != nil || lifespan <= 0 {
+		return 0, errors.New("invalid JWT Lifespan, set JWT_LIFESPAN_MINUTES")
+	}
+	return time.Duration(lifespan) * time.Hour, nil
+}
+
+func GenerateJWT(claims jwt.MapClaims) (string, error) {
+	lifespan, err := AccessTokenLifespan()
 	if err != nil {
-		return "", errors.New("invalid JWT Lifespan")
+		return "", err
 	}
 
-	claims["exp"] = time.Now().Add(time.Duration(lifespan) * time.Hour).Unix()
+	claims["exp"] = time.Now().Add(lifespan).Unix()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if jwtKeyring == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	}
 
-	t, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	key := jwtKeyring.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+
+	t, err := token.SignedString(key.private)
 	if err != nil {
 		return "", err
 	}
@@ -29,16 +81,9 @@ func GenerateJWT(claims jwt.MapClaims) (string, error) {
 }
 
 func DecodeJWT(tokenString string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v\n", token.Header["alg"])
-		}
-
-		return []byte(os.Getenv("JWT_SECRET")), nil
-	})
+	token, err := jwt.Parse(tokenString, verificationKey)
 
 	if err != nil {
-		fmt.Println(err)
 		return nil, err
 	}
 
@@ -49,3 +94,27 @@ func DecodeJWT(tokenString string) (jwt.MapClaims, error) {
 
 	return claims, nil
 }
+
+// verificationKey picks the key by the token's kid. The algorithm has to match the key's, so a token can't
+// pick a weaker one, and HS256 tokens stop being accepted once a keyring is configured.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	if jwtKeyring == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v\n", token.Header["alg"])
+		}
+
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := jwtKeyring.Lookup(kid, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("unknown or retired signing key: %q", kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v\n", token.Header["alg"])
+	}
+
+	return key.public, nil
+}
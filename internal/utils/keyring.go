@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Keyring holds the asymmetric keys JWTs are signed and verified with. Only the active key signs new tokens,
+// the others are kept so tokens they signed still verify until their retirement date.
+type Keyring struct {
+	active  *SigningKey
+	keys    map[string]*SigningKey
+	ordered []*SigningKey // in keyring.json order, so the JWKS output is stable
+}
+
+type SigningKey struct {
+	ID       string
+	Method   jwt.SigningMethod
+	RetireAt *time.Time
+
+	private crypto.Signer // nil for keys that only verify
+	public  crypto.PublicKey
+}
+
+// keyringManifest is the keyring.json file of a keys directory, e.g.
+//
+//	{
+//	  "active": "2024-06",
+//	  "keys": [
+//	    {"kid": "2024-06", "file": "2024-06.pem"},
+//	    {"kid": "2024-01", "file": "2024-01.pub.pem", "retire_at": "2024-06-08T00:00:00Z"}
+//	  ]
+//	}
+type keyringManifest struct {
+	Active string `json:"active"`
+	Keys   []struct {
+		ID       string     `json:"kid"`
+		File     string     `json:"file"`
+		RetireAt *time.Time `json:"retire_at"`
+	} `json:"keys"`
+}
+
+// LoadKeyring reads keyring.json from dir along with the PEM files it lists. RSA keys sign with RS256 and
+// Ed25519 keys with EdDSA. Retired keys may be public keys only, the active one needs its private key.
+func LoadKeyring(dir string) (*Keyring, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, "keyring.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest keyringManifest
+	err = json.Unmarshal(raw, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keyring.json: %w", err)
+	}
+
+	keyring := &Keyring{
+		keys: make(map[string]*SigningKey),
+	}
+	for _, entry := range manifest.Keys {
+		if entry.ID == "" {
+			return nil, errors.New("every key in keyring.json needs a kid")
+		}
+		if _, ok := keyring.keys[entry.ID]; ok {
+			return nil, fmt.Errorf("duplicate kid %s in keyring.json", entry.ID)
+		}
+
+		key, err := loadSigningKey(filepath.Join(dir, entry.File))
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", entry.ID, err)
+		}
+		key.ID = entry.ID
+		key.RetireAt = entry.RetireAt
+		keyring.keys[entry.ID] = key
+		keyring.ordered = append(keyring.ordered, key)
+	}
+
+	active, ok := keyring.keys[manifest.Active]
+	if !ok {
+		return nil, fmt.Errorf("active key %q isn't listed in keyring.json", manifest.Active)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %s has no private key", active.ID)
+	}
+	if active.RetireAt != nil {
+		return nil, fmt.Errorf("active key %s can't have a retirement date", active.ID)
+	}
+	keyring.active = active
+
+	return keyring, nil
+}
+
+func loadSigningKey(path string) (*SigningKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := new(SigningKey)
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, only RSA and Ed25519 are supported", parsed)
+	}
+
+	return key, nil
+}
+
+// Active is the key new tokens are signed with.
+func (k *Keyring) Active() *SigningKey {
+	return k.active
+}
+
+// Lookup returns the key with the given kid, unless it doesn't exist or has been retired.
+func (k *Keyring) Lookup(kid string, now time.Time) (*SigningKey, bool) {
+	key, ok := k.keys[kid]
+	if !ok || key.Retired(now) {
+		return nil, false
+	}
+	return key, true
+}
+
+func (key *SigningKey) Retired(now time.Time) bool {
+	return key.RetireAt != nil && !now.Before(*key.RetireAt)
+}
+
+// JWK is a public key in the JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public part of every key that hasn't been retired yet.
+func (k *Keyring) JWKS(now time.Time) JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(k.ordered))}
+	for _, key := range k.ordered {
+		if key.Retired(now) {
+			continue
+		}
+
+		jwk := JWK{
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: key.Method.Alg(),
+		}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"bytes"
+	stdhtml "html"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// MarkdownRenderVersion has to be bumped whenever the rendered output changes, e.g. a new extension or a stricter
+// sanitizer policy, so posts rendered by an older version get rendered again.
+const MarkdownRenderVersion = 1
+
+const (
+	excerptLength  = 200
+	wordsPerMinute = 200
+)
+
+// raw HTML is let through by goldmark on purpose, the sanitizer decides what's left of it
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM, extension.Footnote),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+var (
+	sanitizer = newSanitizer()
+	stripTags = bluemonday.StrictPolicy()
+)
+
+func newSanitizer() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// task list items
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	// syntax highlighting on the client relies on the language class of fenced code blocks
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	// footnote links and back references
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^footnote(s|-ref|-backref)$`)).OnElements("a", "div", "sup")
+	p.AllowAttrs("role").Matching(regexp.MustCompile(`^doc-(noteref|backlink|endnotes)$`)).OnElements("a", "div")
+	return p
+}
+
+type RenderedMarkdown struct {
+	HTML               string
+	Excerpt            string
+	ReadingTimeMinutes int
+}
+
+// RenderMarkdown renders CommonMark with the GFM extensions (tables, task lists, strikethrough, autolinks) and
+// footnotes to sanitized HTML, along with a plain text excerpt and an estimated reading time.
+func RenderMarkdown(source string) (RenderedMarkdown, error) {
+	var buf bytes.Buffer
+	err := markdown.Convert([]byte(source), &buf)
+	if err != nil {
+		return RenderedMarkdown{}, err
+	}
+
+	sanitized := sanitizer.Sanitize(buf.String())
+	plain := plainText(sanitized)
+
+	return RenderedMarkdown{
+		HTML:               sanitized,
+		Excerpt:            excerpt(plain, excerptLength),
+		ReadingTimeMinutes: readingTime(plain),
+	}, nil
+}
+
+// plainText is taken from the sanitized HTML rather than the source, so markup, and whatever the sanitizer dropped
+// along with it, isn't shown in excerpts or counted as words.
+func plainText(sanitized string) string {
+	return strings.Join(strings.Fields(stdhtml.UnescapeString(stripTags.Sanitize(sanitized))), " ")
+}
+
+// excerpt cuts text to at most n characters without breaking a word.
+func excerpt(text string, n int) string {
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:n])
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+func readingTime(text string) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(words) / wordsPerMinute))
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdownSanitizer(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "script",
+			source:  "hello <script>alert(1)</script>",
+			want:    []string{"hello"},
+			notWant: []string{"<script", "alert(1)"},
+		},
+		{
+			name:    "event handler",
+			source:  `<img src="/a.png" onerror="alert(1)">`,
+			want:    []string{`src="/a.png"`},
+			notWant: []string{"onerror"},
+		},
+		{
+			name:    "javascript link",
+			source:  "[click](javascript:alert(1))",
+			want:    []string{"click"},
+			notWant: []string{"javascript:"},
+		},
+		{
+			name:   "task list",
+			source: "- [x] done\n- [ ] todo",
+			want:   []string{`type="checkbox"`, "checked", "disabled"},
+		},
+		{
+			name:   "fenced code language",
+			source: "```go\nfmt.Println()\n```",
+			want:   []string{`class="language-go"`},
+		},
+		{
+			name:    "arbitrary class",
+			source:  `<p class="evil">text</p>`,
+			want:    []string{"text"},
+			notWant: []string{"evil"},
+		},
+		{
+			name:   "footnote",
+			source: "text[^1]\n\n[^1]: note",
+			want:   []string{`class="footnote-ref"`, `role="doc-noteref"`, `class="footnotes"`},
+		},
+		{
+			name:   "table",
+			source: "| a | b |\n| - | - |\n| 1 | 2 |",
+			want:   []string{"<table>", "<td>1</td>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderMarkdown(tt.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(got.HTML, s) {
+					t.Errorf("RenderMarkdown(%q) = %q, want it to contain %q", tt.source, got.HTML, s)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(got.HTML, s) {
+					t.Errorf("RenderMarkdown(%q) = %q, want it not to contain %q", tt.source, got.HTML, s)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownExcerpt(t *testing.T) {
+	got, err := RenderMarkdown("# Title\n\nSome **bold** text <script>hidden()</script>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Title Some bold text"; got.Excerpt != want {
+		t.Errorf("RenderMarkdown().Excerpt = %q, want %q", got.Excerpt, want)
+	}
+	if got.ReadingTimeMinutes != 1 {
+		t.Errorf("RenderMarkdown().ReadingTimeMinutes = %d, want 1", got.ReadingTimeMinutes)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{text: "short", n: 10, want: "short"},
+		{text: "hello world again", n: 13, want: "hello world…"},
+		{text: "hello, world", n: 8, want: "hello…"},
+		{text: "héllo wörld", n: 7, want: "héllo…"},
+	}
+
+	for _, tt := range tests {
+		if got := excerpt(tt.text, tt.n); got != tt.want {
+			t.Errorf("excerpt(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+	tests := map[int]int{
+		0:   0,
+		1:   1,
+		200: 1,
+		201: 2,
+		600: 3,
+	}
+
+	for words, want := range tests {
+		if got := readingTime(strings.Repeat("word ", words)); got != want {
+			t.Errorf("readingTime(%d words) = %d, want %d", words, got, want)
+		}
+	}
+}
@@ -1,6 +1,13 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrNoPassword means the user hasn't set a password, they log in some other way.
+var ErrNoPassword = errors.New("no password set")
 
 func HashPassword(raw string) ([]byte, error) {
 	password, err := bcrypt.GenerateFromPassword([]byte(raw), 5)
@@ -11,5 +18,8 @@ func HashPassword(raw string) ([]byte, error) {
 }
 
 func IsValidPassword(password []byte, raw string) error {
+	if len(password) == 0 {
+		return ErrNoPassword
+	}
 	return bcrypt.CompareHashAndPassword(password, []byte(raw))
 }
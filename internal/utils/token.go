@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, so they're easy to tell apart from JWTs and to spot in leaked code.
+const PersonalAccessTokenPrefix = "gbp_"
+
+// GenerateRandomToken returns a URL-safe random string built from n random bytes.
+func GenerateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken hashes opaque tokens before they are stored, so a database leak doesn't leak usable tokens.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func RefreshTokenLifespan() (time.Duration, error) {
+	lifespan, err := strconv.Atoi(os.Getenv("REFRESH_TOKEN_LIFESPAN"))
+	if err != nil {
+		return 0, errors.New("invalid refresh token lifespan")
+	}
+
+	return time.Duration(lifespan) * time.Hour, nil
+}
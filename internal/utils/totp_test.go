@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// The SHA1 test vectors of RFC 6238 appendix B, truncated to 6 digits. The secret is "12345678901234567890" in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		time     int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "59", secret: rfcSecret, code: "287082", time: 59, wantStep: 1, wantOK: true},
+		{name: "1111111109", secret: rfcSecret, code: "081804", time: 1111111109, wantStep: 37037036, wantOK: true},
+		{name: "1234567890", secret: rfcSecret, code: "005924", time: 1234567890, wantStep: 41152263, wantOK: true},
+		{name: "2000000000", secret: rfcSecret, code: "279037", time: 2000000000, wantStep: 66666666, wantOK: true},
+		{name: "lowercase secret", secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", code: "287082", time: 59, wantStep: 1, wantOK: true},
+		{name: "previous period", secret: rfcSecret, code: "081804", time: 1111111109 + 30, wantStep: 37037036, wantOK: true},
+		{name: "next period", secret: rfcSecret, code: "081804", time: 1111111109 - 30, wantStep: 37037036, wantOK: true},
+		{name: "outside the skew", secret: rfcSecret, code: "081804", time: 1111111109 + 60},
+		{name: "wrong code", secret: rfcSecret, code: "000000", time: 59},
+		{name: "wrong length", secret: rfcSecret, code: "94287082", time: 59},
+		{name: "invalid secret", secret: "not base32!", code: "287082", time: 59},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(tt.secret, tt.code, time.Unix(tt.time, 0))
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("ValidateTOTP() = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := totpCode(mustDecodeSecret(t, secret), time.Now().Unix()/totpPeriod)
+	if _, ok := ValidateTOTP(secret, code, time.Now()); !ok {
+		t.Errorf("ValidateTOTP(%q, %q) = false, want true", secret, code)
+	}
+}
+
+func mustDecodeSecret(t *testing.T, secret string) []byte {
+	t.Helper()
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}